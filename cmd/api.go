@@ -7,12 +7,19 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/faults"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_server"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/ratelimit"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/scaffold"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
+	"github.com/twitchdev/twitch-cli/internal/util"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // getCmd represents the get command
@@ -22,6 +29,29 @@ var prettyPrint bool
 var autoPaginate int = 0
 var port int
 var verbose bool
+var outputFormat string
+var outputFields string
+var resumeFile string
+var resume bool
+var batchConcurrency int
+var skipValidation bool
+var exportFormat string
+var harFile string
+var includeToken bool
+var useCache bool
+var noCache bool
+var refreshCache bool
+var cacheTTL time.Duration
+var runVars []string
+var diffAgainst string
+var diffValues bool
+var diffInput string
+var rateLimit int
+var rateLimitConfig string
+var faultsFile string
+var scaffoldSpec string
+var scaffoldEndpointsDir string
+var scaffoldCatalogFile string
 
 var generateCount int
 
@@ -66,6 +96,85 @@ var putCmd = &cobra.Command{
 	RunE:      cmdRun,
 }
 
+var batchCmd = &cobra.Command{
+	Use:   "batch <file>",
+	Short: "Runs requests from an NDJSON file concurrently, writing an NDJSON result with the status code and response of each. Use - to read from stdin.",
+	Example: `twitch api batch requests.ndjson > results.ndjson
+echo '{"method":"GET","path":"/users","query":{"login":["twitchdev","twitch"]}}' | twitch api batch -`,
+	Args: cobra.ExactArgs(1),
+	RunE: batchCmdRun,
+}
+
+var describeCmd = &cobra.Command{
+	Use:       "describe <path>",
+	Short:     "Describes an endpoint's methods, query parameters, body, required scopes and pagination support.",
+	Example:   "twitch api describe streams/markers\ntwitch api describe /users -o json",
+	Args:      cobra.MinimumNArgs(1),
+	ValidArgs: api.ValidOptions("GET"),
+	RunE:      describeCmdRun,
+}
+
+var saveCmd = &cobra.Command{
+	Use:   "save <name> <method> <path>",
+	Short: "Saves a request to send later with `twitch api run`. Its path, query parameters and body can use Go template variables, such as {{.me}} for the user ID of the token.",
+	Example: `twitch api save mods-of-main get /moderation/moderators -q broadcaster_id={{.me}}
+twitch api save title patch /channels -q broadcaster_id={{.me}} -b '{"title":"{{.title}}"}'`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: saveCmdRun,
+}
+
+var savedCmd = &cobra.Command{
+	Use:   "saved",
+	Short: "Lists the requests saved with `twitch api save`.",
+	Args:  cobra.NoArgs,
+	RunE:  savedCmdRun,
+}
+
+var savedDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Deletes a saved request.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  savedDeleteCmdRun,
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Sends a request saved with `twitch api save`, filling in its template variables.",
+	Example: `twitch api run mods-of-main
+twitch api run title --var title="Speedrunning"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCmdRun,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <method> <path>",
+	Short: "Sends a request with the active profile and the profile given with --against, such as the mock API, and reports structural differences between the responses.",
+	Example: `twitch api diff get /users -q login=twitchdev --against mock
+twitch api diff --input requests.ndjson --against mock -o json > fidelity.ndjson`,
+	RunE: diffCmdRun,
+}
+
+var exploreCmd = &cobra.Command{
+	Use:   "explore",
+	Short: "Interactively pick an endpoint from the catalog, fill in its query parameters and body, and page through the responses. Requests can be saved as snippets.",
+	Example: `twitch api explore
+twitch api explore --profile mock`,
+	Args: cobra.NoArgs,
+	RunE: exploreCmdRun,
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Used to manage the cache of GET responses enabled with --cache or the api_cache setting.",
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Removes every cached response, for every profile.",
+	Args:  cobra.NoArgs,
+	RunE:  cacheClearCmdRun,
+}
+
 var mockCmd = &cobra.Command{
 	Use:   "mock-api",
 	Short: "Used to interface with the mock Twitch API.",
@@ -77,6 +186,15 @@ var startCmd = &cobra.Command{
 	RunE:  mockStartRun,
 }
 
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold",
+	Short: "Generates mock endpoints and endpoint catalog entries from an OpenAPI description of the Twitch API. Hand-written endpoints are left untouched.",
+	Example: `twitch mock-api scaffold --spec helix.openapi.yaml
+HELIX_OPENAPI=$PWD/helix.openapi.yaml go generate ./internal/mock_api/endpoints`,
+	Args: cobra.NoArgs,
+	RunE: scaffoldCmdRun,
+}
+
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Used to randomly generate data for use with the mock API. By default, this is run on the first invocation of the start command, however this allows you to generate further primitives.",
@@ -86,7 +204,9 @@ var generateCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(apiCmd, mockCmd)
 
-	apiCmd.AddCommand(getCmd, postCmd, patchCmd, deleteCmd, putCmd)
+	apiCmd.AddCommand(getCmd, postCmd, patchCmd, deleteCmd, putCmd, batchCmd, describeCmd, exploreCmd, cacheCmd, saveCmd, savedCmd, runCmd, diffCmd)
+	savedCmd.AddCommand(savedDeleteCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
 
 	apiCmd.PersistentFlags().StringArrayVarP(&queryParameters, "query-params", "q", nil, "Available multiple times. Passes in query parameters to endpoints using the format of `key=value`.")
 	apiCmd.PersistentFlags().StringVarP(&body, "body", "b", "", "Passes a body to the request. Alteratively supports CURL-like references to files using the format of `@data,json`.")
@@ -97,27 +217,66 @@ func init() {
 	apiCmd.PersistentFlags().MarkHidden("pretty-print")
 
 	apiCmd.PersistentFlags().BoolVarP(&prettyPrint, "unformatted", "u", false, "Whether to have API requests come back unformatted/non-prettyprinted. Default is false.")
+	apiCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", api.OutputJSON, fmt.Sprintf("Format to print responses in. Valid formats are: %v. ndjson prints one item of data per line as each page arrives.", strings.Join(api.OutputFormats, ", ")))
+	apiCmd.PersistentFlags().StringVar(&outputFields, "fields", "", "Comma separated jq-style paths to select from each item of data, e.g. `.id,.login,.tags[0]`.")
+	apiCmd.PersistentFlags().BoolVar(&skipValidation, "skip-validation", false, "Sends requests without checking their query parameters and body against the endpoint catalog.")
+	apiCmd.RegisterFlagCompletionFunc("query-params", completeQueryParameters)
+	apiCmd.PersistentFlags().StringVar(&exportFormat, "export", "", fmt.Sprintf("Prints the request in another format instead of sending it. Valid formats are: %v.", strings.Join(api.ExportFormats, ", ")))
+	apiCmd.PersistentFlags().StringVar(&harFile, "har", "", "Appends every request and response to this HAR archive.")
+	apiCmd.PersistentFlags().BoolVar(&includeToken, "include-token", false, "Includes the access token in --export and --har output, instead of replacing it with $TWITCH_TOKEN.")
 
 	getCmd.PersistentFlags().IntVarP(&autoPaginate, "autopaginate", "P", 0, "Whether to have API requests automatically paginate. Default is to not paginate.")
 	getCmd.PersistentFlags().Lookup("autopaginate").NoOptDefVal = "0"
+	getCmd.PersistentFlags().StringVar(&resumeFile, "resume-file", "", "With --autopaginate, saves the cursor for the next page to this file after every page, so the request can be continued with --resume.")
+	getCmd.PersistentFlags().BoolVar(&resume, "resume", false, "Continues autopaginating from the cursor saved in --resume-file.")
+	getCmd.PersistentFlags().BoolVar(&useCache, "cache", false, "Serves responses from the on-disk response cache, and stores new ones in it. Set api_cache=true in the configuration to always cache.")
+	getCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Neither reads from nor writes to the response cache, even if api_cache is set.")
+	getCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Fetches a fresh response and stores it in the response cache, ignoring any cached response.")
+	getCmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 0, "How long responses are cached for, e.g. `30m`. Defaults to a TTL for each endpoint.")
+
+	runCmd.Flags().StringArrayVar(&runVars, "var", nil, "Available multiple times. Sets a template variable using the format of `key=value`.")
+	runCmd.Flags().IntVarP(&autoPaginate, "autopaginate", "P", 0, "Whether to have GET requests automatically paginate. Default is to not paginate.")
+	runCmd.Flags().Lookup("autopaginate").NoOptDefVal = "0"
+
+	diffCmd.Flags().StringVar(&diffAgainst, "against", "", "Profile to compare responses with, e.g. a profile for the mock API.")
+	diffCmd.Flags().BoolVar(&diffValues, "values", false, "Also reports differing values, comparing arrays item by item.")
+	diffCmd.Flags().StringVar(&diffInput, "input", "", "NDJSON file of requests in the batch format, to compare many endpoints at once. Use - to read from stdin.")
+	diffCmd.MarkFlagRequired("against")
+
+	batchCmd.Flags().IntVarP(&batchConcurrency, "concurrency", "c", 5, "Number of requests to run at once.")
 
-	mockCmd.AddCommand(startCmd, generateCmd)
+	mockCmd.AddCommand(startCmd, generateCmd, scaffoldCmd)
 
 	startCmd.Flags().IntVarP(&port, "port", "p", 8080, "Defines the port that the mock API will run on.")
+	startCmd.Flags().IntVar(&rateLimit, "ratelimit", 0, fmt.Sprintf("Number of requests per minute allowed per client and user before returning 429, e.g. %v as in production. Rate limiting is off by default.", ratelimit.DefaultLimit))
+	startCmd.Flags().StringVar(&rateLimitConfig, "ratelimit-config", "", "Path to a JSON file defining the default and per-endpoint rate limit buckets.")
+	startCmd.Flags().StringVar(&faultsFile, "faults", "", "Path to a JSON file of fault injection rules to apply to mock endpoints.")
 
 	generateCmd.Flags().IntVarP(&generateCount, "count", "c", 25, "Defines the number of fake users to generate.")
+
+	scaffoldCmd.Flags().StringVar(&scaffoldSpec, "spec", "", "Path to an OpenAPI 3 or Swagger 2 description of the Twitch API, in YAML or JSON.")
+	scaffoldCmd.Flags().StringVar(&scaffoldEndpointsDir, "endpoints-dir", "internal/mock_api/endpoints", "Directory of the mock endpoint packages.")
+	scaffoldCmd.Flags().StringVar(&scaffoldCatalogFile, "catalog", "internal/api/catalog_gen.go", "File to write the generated endpoint catalog entries to.")
+	scaffoldCmd.MarkFlagRequired("spec")
 }
 
-func cmdRun(cmd *cobra.Command, args []string) error {
-	var path string
+// pathFromArgs joins the arguments to api commands into an endpoint path, e.g. `users extensions` or `/users/extensions`
+func pathFromArgs(args []string) string {
+	if len(args) == 1 && args[0][:1] == "/" {
+		return args[0]
+	}
+	return "/" + strings.Join(args[:], "/")
+}
 
+func cmdRun(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		cmd.Help()
 		return fmt.Errorf("")
-	} else if len(args) == 1 && args[0][:1] == "/" {
-		path = args[0]
-	} else {
-		path = "/" + strings.Join(args[:], "/")
+	}
+	path := pathFromArgs(args)
+
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
 	}
 
 	if body != "" && body[:1] == "@" {
@@ -128,11 +287,306 @@ func cmdRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	p := api.RequestParameters{
+		Method:          cmd.Name(),
+		Path:            path,
+		QueryParameters: queryParameters,
+		Body:            []byte(body),
+		PrettyPrint:     !prettyPrint,
+		Verbose:         verbose,
+		Output:          outputFormat,
+		Fields:          outputFields,
+		SkipValidation:  skipValidation,
+		Export:          exportFormat,
+		HARFile:         harFile,
+		IncludeToken:    includeToken,
+	}
 	if cmd.Name() == "get" && cmd.PersistentFlags().Lookup("autopaginate").Changed {
-		return api.NewRequest(cmd.Name(), path, queryParameters, []byte(body), !prettyPrint, &autoPaginate, verbose)
+		p.Autopaginate = &autoPaginate // only set on when the user changed the flag
+	}
+	if cmd.Name() == "get" {
+		if resume && resumeFile == "" {
+			return fmt.Errorf("--resume requires --resume-file")
+		}
+		if resumeFile != "" && p.Autopaginate == nil {
+			return fmt.Errorf("--resume-file requires --autopaginate")
+		}
+		p.ResumeFile = resumeFile
+		p.Resume = resume
+
+		if noCache && (useCache || refreshCache) {
+			return fmt.Errorf("--no-cache can't be used with --cache or --refresh")
+		}
+		if cacheTTL < 0 {
+			return fmt.Errorf("--cache-ttl must be positive")
+		}
+		p.Cache = (useCache || refreshCache || viper.GetBool("api_cache")) && !noCache
+		p.RefreshCache = refreshCache
+		p.CacheTTL = cacheTTL
+	}
+	return api.NewRequest(p)
+}
+
+func batchCmdRun(cmd *cobra.Command, args []string) error {
+	if batchConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	if exportFormat != "" {
+		return fmt.Errorf("--export isn't supported by batch")
+	}
+
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
+	}
+
+	input := os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	return api.RunBatch(api.BatchParameters{
+		Input:          input,
+		Output:         os.Stdout,
+		Concurrency:    batchConcurrency,
+		SkipValidation: skipValidation,
+		HARFile:        harFile,
+		IncludeToken:   includeToken,
+	})
+}
+
+func describeCmdRun(cmd *cobra.Command, args []string) error {
+	// --output defaults to json for requests; descriptions are text unless it's set
+	output := ""
+	if cmd.Flags().Changed("output") {
+		if outputFormat != api.OutputJSON {
+			return fmt.Errorf("describe only supports --output json")
+		}
+		output = api.OutputJSON
+	}
+	return api.DescribeEndpoint(os.Stdout, pathFromArgs(args), output)
+}
+
+func saveCmdRun(cmd *cobra.Command, args []string) error {
+	method := strings.ToUpper(args[1])
+	switch method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		return fmt.Errorf("Invalid method %v. Valid methods are: GET, POST, PUT, PATCH, DELETE", args[1])
+	}
+
+	if body != "" && body[:1] == "@" {
+		var err error
+		body, err = getBodyFromFile(body[1:])
+		if err != nil {
+			return err
+		}
+	}
+
+	r := api.SavedRequest{
+		Name:            args[0],
+		Method:          method,
+		Path:            pathFromArgs(args[2:]),
+		QueryParameters: queryParameters,
+		Body:            body,
+	}
+	if err := api.SaveRequest(r); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %v. Send it with `twitch api run %v`.\n", r.Name, r.Name)
+	return nil
+}
+
+func savedCmdRun(cmd *cobra.Command, args []string) error {
+	requests, err := api.SavedRequests()
+	if err != nil {
+		return err
+	}
+	if len(requests) == 0 {
+		fmt.Println("No saved requests. Save one with `twitch api save`.")
+		return nil
+	}
+	for _, r := range requests {
+		fmt.Printf("%v\t%v\n", r.Name, r.Command())
+	}
+	return nil
+}
+
+func savedDeleteCmdRun(cmd *cobra.Command, args []string) error {
+	if err := api.DeleteSavedRequest(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %v\n", args[0])
+	return nil
+}
+
+func runCmdRun(cmd *cobra.Command, args []string) error {
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
+	}
+
+	saved, err := api.GetSavedRequest(args[0])
+	if err != nil {
+		return err
+	}
+	vars, err := api.ParseVariables(runVars)
+	if err != nil {
+		return err
+	}
+	r, err := saved.Render(vars)
+	if err != nil {
+		return err
+	}
+
+	// -q adds to the saved query parameters, and -b replaces the saved body
+	if body != "" && body[:1] == "@" {
+		body, err = getBodyFromFile(body[1:])
+		if err != nil {
+			return err
+		}
+	}
+	if body != "" {
+		r.Body = body
+	}
+
+	p := api.RequestParameters{
+		Method:          r.Method,
+		Path:            r.Path,
+		QueryParameters: append(r.QueryParameters, queryParameters...),
+		Body:            []byte(r.Body),
+		PrettyPrint:     !prettyPrint,
+		Verbose:         verbose,
+		Output:          outputFormat,
+		Fields:          outputFields,
+		SkipValidation:  skipValidation,
+		Export:          exportFormat,
+		HARFile:         harFile,
+		IncludeToken:    includeToken,
+	}
+	if cmd.Flags().Lookup("autopaginate").Changed {
+		if r.Method != "GET" {
+			return fmt.Errorf("--autopaginate can only be used with GET requests")
+		}
+		p.Autopaginate = &autoPaginate
+	}
+	return api.NewRequest(p)
+}
+
+func diffCmdRun(cmd *cobra.Command, args []string) error {
+	var requests []api.BatchRequest
+	if diffInput != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("A request can't be given with --input")
+		}
+		input := os.Stdin
+		if diffInput != "-" {
+			f, err := os.Open(diffInput)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			input = f
+		}
+		var err error
+		requests, err = api.ReadBatchRequests(input)
+		if err != nil {
+			return err
+		}
 	} else {
-		return api.NewRequest(cmd.Name(), path, queryParameters, []byte(body), !prettyPrint, nil, verbose) // only set on when the user changed the flag
+		if len(args) < 2 {
+			cmd.Help()
+			return fmt.Errorf("")
+		}
+		if body != "" && body[:1] == "@" {
+			var err error
+			body, err = getBodyFromFile(body[1:])
+			if err != nil {
+				return err
+			}
+		}
+		query := api.BatchQuery{}
+		for _, q := range queryParameters {
+			k, v, _ := strings.Cut(q, "=")
+			query[k] = append(query[k], v)
+		}
+		requests = []api.BatchRequest{{Method: strings.ToUpper(args[0]), Path: pathFromArgs(args[1:]), Query: query}}
+		if body != "" {
+			requests[0].Body = []byte(body)
+		}
 	}
+
+	// --output defaults to json for requests; reports are text unless it's set
+	format := ""
+	if cmd.Flags().Changed("output") {
+		if outputFormat != api.OutputJSON {
+			return fmt.Errorf("diff only supports --output json")
+		}
+		format = api.OutputJSON
+	}
+
+	path, err := util.GetProfileConfigPath(diffAgainst)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) && diffAgainst != util.DefaultProfile {
+		return fmt.Errorf("Profile %v does not exist. Create it with `twitch configure --profile %v`", diffAgainst, diffAgainst)
+	}
+	if diffAgainst == util.GetProfile() {
+		return fmt.Errorf("--against must be a different profile than the active profile, %v", diffAgainst)
+	}
+
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
+	}
+	return api.Diff(api.DiffParameters{
+		Requests: requests,
+		Profiles: [2]string{util.GetProfile(), diffAgainst},
+		UseProfile: func(profile string) error {
+			if err := useProfile(profile); err != nil {
+				return err
+			}
+			return secrets.LoadIntoConfig()
+		},
+		Values:         diffValues,
+		SkipValidation: skipValidation,
+		Output:         os.Stdout,
+		Format:         format,
+	})
+}
+
+func exploreCmdRun(cmd *cobra.Command, args []string) error {
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
+	}
+	return api.Explore()
+}
+
+func cacheClearCmdRun(cmd *cobra.Command, args []string) error {
+	removed, err := api.ClearCache()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed %v cached responses\n", removed)
+	return nil
+}
+
+// completeQueryParameters completes `key=` for the query parameters of the endpoint given in args
+func completeQueryParameters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 || args[0] == "" || strings.Contains(toComplete, "=") {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := []string{}
+	for _, name := range api.ParameterNames(cmd.Name(), pathFromArgs(args)) {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name+"=")
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
 }
 
 func getBodyFromFile(filename string) (string, error) {
@@ -145,8 +599,56 @@ func getBodyFromFile(filename string) (string, error) {
 }
 
 func mockStartRun(cmd *cobra.Command, args []string) error {
+	opts := mock_server.ServerOptions{}
+
+	if rateLimit > 0 || rateLimitConfig != "" {
+		c := ratelimit.DefaultConfig(rateLimit)
+		if rateLimit <= 0 {
+			c.Default.Limit = ratelimit.DefaultLimit
+		}
+		if rateLimitConfig != "" {
+			var err error
+			c, err = ratelimit.LoadConfig(rateLimitConfig, c)
+			if err != nil {
+				return err
+			}
+		}
+		opts.RateLimiter = ratelimit.NewLimiter(c)
+	}
+
+	opts.Faults = faults.NewEngine()
+	if faultsFile != "" {
+		if err := opts.Faults.LoadFile(faultsFile); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("Starting mock API server on http://localhost:%v", port)
-	return mock_server.StartServer(port)
+	return mock_server.StartServer(port, opts)
+}
+
+func scaffoldCmdRun(cmd *cobra.Command, args []string) error {
+	paths, err := scaffold.LoadSpec(scaffoldSpec)
+	if err != nil {
+		return err
+	}
+
+	result, err := scaffold.Generate(paths, scaffold.Options{
+		EndpointsDir: scaffoldEndpointsDir,
+		CatalogFile:  scaffoldCatalogFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range result.Created {
+		fmt.Printf("Created %v\n", f)
+	}
+	for _, s := range result.Skipped {
+		fmt.Printf("Skipped %v\n", s)
+	}
+	fmt.Printf("Generated %v mock endpoints and %v catalog entries\n", len(result.Generated), len(paths))
+	return nil
 }
 
 func generateMockRun(cmd *cobra.Command, args []string) error {
@@ -5,7 +5,9 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/twitchdev/twitch-cli/internal/secrets"
 	"github.com/twitchdev/twitch-cli/internal/util"
 
 	"github.com/manifoldco/promptui"
@@ -15,6 +17,10 @@ import (
 
 var clientID string
 var clientSecret string
+var configureBaseURL string
+var configureAuthBaseURL string
+var configureSecretStore string
+var migrateSecretsTo string
 
 // configureCmd represents the configure command
 var configureCmd = &cobra.Command{
@@ -23,15 +29,40 @@ var configureCmd = &cobra.Command{
 	RunE:  configureCmdRun,
 }
 
+var migrateSecretsCmd = &cobra.Command{
+	Use:     "migrate-secrets",
+	Short:   "Moves the Client Secret, access token and refresh token of the current profile into the selected secret store.",
+	Example: `TWITCH_SECRETS_PASSPHRASE=... twitch configure migrate-secrets --to encrypted`,
+	Args:    cobra.NoArgs,
+	RunE:    migrateSecretsCmdRun,
+}
+
 func init() {
 	rootCmd.AddCommand(configureCmd)
+	configureCmd.AddCommand(migrateSecretsCmd)
 
 	configureCmd.Flags().StringVarP(&clientID, "client-id", "i", "", "Client ID to use.")
 	configureCmd.Flags().StringVarP(&clientSecret, "client-secret", "s", "", "Client Secret to use.")
+	configureCmd.Flags().StringVar(&configureBaseURL, "base-url", "", "Base URL of the API used by `twitch api`, e.g. http://localhost:8080/mock for the mock API.")
+	configureCmd.Flags().StringVar(&configureAuthBaseURL, "auth-url", "", "Base URL of the OAuth server used by `twitch token`, e.g. http://localhost:8080/auth for the mock API.")
+	configureCmd.Flags().StringVar(&configureSecretStore, "secret-store", "", fmt.Sprintf("Where to store the Client Secret and tokens. Valid values are: %v, %v. Use `twitch configure migrate-secrets` to move existing secrets.", secrets.StorePlain, secrets.StoreEncrypted))
+
+	migrateSecretsCmd.Flags().StringVar(&migrateSecretsTo, "to", secrets.StoreEncrypted, fmt.Sprintf("Secret store to move secrets to. Valid values are: %v, %v", secrets.StorePlain, secrets.StoreEncrypted))
 }
 
 func configureCmdRun(cmd *cobra.Command, args []string) error {
 	var err error
+	if configureSecretStore != "" {
+		// load secrets from the current store first, so the ones not being configured (e.g. tokens) move to the new store too
+		if err := secrets.LoadIntoConfig(); err != nil {
+			return err
+		}
+		viper.Set(secrets.StoreConfigKey, configureSecretStore)
+		if _, err := secrets.GetStore(); err != nil {
+			return err
+		}
+	}
+
 	if clientID == "" {
 		clientIDPrompt := promptui.Prompt{
 			Label: "Client ID",
@@ -44,6 +75,9 @@ func configureCmdRun(cmd *cobra.Command, args []string) error {
 		}
 
 		clientID, err = clientIDPrompt.Run()
+		if err != nil {
+			return err
+		}
 	}
 
 	if clientSecret == "" {
@@ -58,6 +92,9 @@ func configureCmdRun(cmd *cobra.Command, args []string) error {
 		}
 
 		clientSecret, err = clientSecretPrompt.Run()
+		if err != nil {
+			return err
+		}
 	}
 
 	if clientID == "" && clientSecret == "" {
@@ -66,16 +103,35 @@ func configureCmdRun(cmd *cobra.Command, args []string) error {
 
 	viper.Set("clientId", clientID)
 	viper.Set("clientSecret", clientSecret)
-
-	configPath, err := util.GetConfigPath()
-	if err != nil {
-		return err
+	if configureBaseURL != "" {
+		viper.Set("BASE_URL", configureBaseURL)
+	}
+	if configureAuthBaseURL != "" {
+		viper.Set("AUTH_BASE_URL", configureAuthBaseURL)
 	}
 
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	if err := secrets.WriteConfig(); err != nil {
 		return fmt.Errorf("Failed to write configuration: %v", err.Error())
 	}
 
-	fmt.Println("Updated configuration.")
+	if util.GetProfile() != util.DefaultProfile {
+		fmt.Printf("Updated configuration for profile %v.\n", util.GetProfile())
+	} else {
+		fmt.Println("Updated configuration.")
+	}
+	return nil
+}
+
+func migrateSecretsCmdRun(cmd *cobra.Command, args []string) error {
+	moved, err := secrets.Migrate(migrateSecretsTo)
+	if err != nil {
+		return fmt.Errorf("Failed to migrate secrets: %v", err.Error())
+	}
+
+	if len(moved) == 0 {
+		fmt.Printf("No secrets found; profile %v will use the %v secret store.\n", util.GetProfile(), migrateSecretsTo)
+	} else {
+		fmt.Printf("Moved %v to the %v secret store for profile %v.\n", strings.Join(moved, ", "), migrateSecretsTo, util.GetProfile())
+	}
 	return nil
 }
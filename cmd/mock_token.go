@@ -0,0 +1,197 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_auth"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var mockTokenUser string
+var mockTokenScopes string
+var mockTokenClientID string
+var mockTokenExpiresIn time.Duration
+var mockTokenWriteConfig bool
+var mockTokenPort int
+
+var mockTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manages access tokens for the mock API directly in its database, without going through an OAuth flow.",
+}
+
+var mockTokenCreateCmd = &cobra.Command{
+	Use:     "create",
+	Short:   "Creates an access token for the mock API. Creates a user access token when --user is set, otherwise an app access token.",
+	Example: `twitch mock-api token create --user 12345 --scopes "bits:read user:read:email" --write-config`,
+	Args:    cobra.NoArgs,
+	RunE:    mockTokenCreateRun,
+}
+
+var mockTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists access tokens for the mock API.",
+	Args:  cobra.NoArgs,
+	RunE:  mockTokenListRun,
+}
+
+var mockTokenExpireCmd = &cobra.Command{
+	Use:   "expire <token>...",
+	Short: "Expires one or more access tokens immediately. Their refresh tokens remain valid.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  mockTokenExpireRun,
+}
+
+var mockTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke <token>...",
+	Short: "Revokes one or more access tokens and their refresh tokens.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  mockTokenRevokeRun,
+}
+
+func init() {
+	mockCmd.AddCommand(mockTokenCmd)
+	mockTokenCmd.AddCommand(mockTokenCreateCmd, mockTokenListCmd, mockTokenExpireCmd, mockTokenRevokeCmd)
+
+	mockTokenCreateCmd.Flags().StringVar(&mockTokenUser, "user", "", "ID or login of the user to create a user access token for. Creates an app access token if not set.")
+	mockTokenCreateCmd.Flags().StringVarP(&mockTokenScopes, "scopes", "s", "", "Space separated scopes to include in the token.")
+	mockTokenCreateCmd.Flags().StringVar(&mockTokenClientID, "client-id", "", "Client to issue the token to. Defaults to the first non-extension client in the mock database.")
+	mockTokenCreateCmd.Flags().DurationVar(&mockTokenExpiresIn, "expires-in", 0, "How long the token is valid for. Defaults to the client's token lifetime.")
+	mockTokenCreateCmd.Flags().BoolVar(&mockTokenWriteConfig, "write-config", false, "Write the token, its client, and the mock API's URLs to the CLI config so `twitch api` uses the mock API.")
+	mockTokenCreateCmd.Flags().IntVarP(&mockTokenPort, "port", "p", 8080, "Port the mock API runs on. Only used with --write-config.")
+
+	mockTokenListCmd.Flags().StringVar(&mockTokenUser, "user", "", "Only list tokens for the user with this ID or login.")
+	mockTokenListCmd.Flags().StringVar(&mockTokenClientID, "client-id", "", "Only list tokens issued to this client.")
+}
+
+func mockTokenCreateRun(cmd *cobra.Command, args []string) error {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+
+	auth, client, err := mock_auth.CreateToken(db, mock_auth.CreateTokenParameters{
+		ClientID:  mockTokenClientID,
+		User:      mockTokenUser,
+		Scopes:    strings.Fields(mockTokenScopes),
+		ExpiresIn: mockTokenExpiresIn,
+	})
+	if err != nil {
+		return err
+	}
+
+	lightYellow := color.New(color.FgHiYellow).PrintfFunc()
+	white := color.New(color.FgWhite).SprintfFunc()
+
+	if auth.UserID != "" {
+		lightYellow("User Access Token: %v\n", white(auth.Token))
+		lightYellow("Refresh Token: %v\n", white(auth.RefreshToken))
+		lightYellow("User ID: %v\n", white(auth.UserID))
+	} else {
+		lightYellow("App Access Token: %v\n", white(auth.Token))
+	}
+	lightYellow("Client ID: %v\n", white(client.ID))
+	lightYellow("Client Secret: %v\n", white(client.Secret))
+	lightYellow("Expires At: %v\n", white(auth.ExpiresAt))
+	if auth.Scopes == "" {
+		lightYellow("Scopes: %v\n", white("None"))
+	} else {
+		lightYellow("Scopes: %v\n", white(auth.Scopes))
+	}
+
+	if !mockTokenWriteConfig {
+		return nil
+	}
+
+	viper.Set("clientId", client.ID)
+	viper.Set("clientSecret", client.Secret)
+	viper.Set("accessToken", auth.Token)
+	viper.Set("refreshToken", auth.RefreshToken)
+	viper.Set("tokenScopes", strings.Fields(auth.Scopes))
+	viper.Set("tokenExpiration", auth.ExpiresAt)
+	viper.Set("BASE_URL", fmt.Sprintf("http://localhost:%v/mock", mockTokenPort))
+	viper.Set("AUTH_BASE_URL", fmt.Sprintf("http://localhost:%v/auth", mockTokenPort))
+
+	if err := secrets.WriteConfig(); err != nil {
+		return fmt.Errorf("Failed to write configuration: %v", err.Error())
+	}
+
+	if util.GetProfile() != util.DefaultProfile {
+		fmt.Printf("Updated configuration for profile %v. `twitch api` will use the mock API with this profile.\n", util.GetProfile())
+	} else {
+		fmt.Println("Updated configuration. `twitch api` will now use the mock API; remove BASE_URL and AUTH_BASE_URL from the config to use production again, or use a separate profile with --profile.")
+	}
+	return nil
+}
+
+func mockTokenListRun(cmd *cobra.Command, args []string) error {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+
+	tokens, err := mock_auth.ListTokens(db, mockTokenUser, mockTokenClientID)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tTYPE\tCLIENT ID\tUSER ID\tEXPIRES AT\tSCOPES")
+	for _, t := range tokens {
+		tokenType := "app"
+		if t.UserID != "" {
+			tokenType = "user"
+		}
+
+		expiresAt := t.ExpiresAt
+		if e, err := time.Parse(time.RFC3339, t.ExpiresAt); err == nil && util.GetTimestamp().After(e) {
+			expiresAt += " (expired)"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", t.Token, tokenType, t.ClientID, t.UserID, expiresAt, t.Scopes)
+	}
+	return w.Flush()
+}
+
+func mockTokenExpireRun(cmd *cobra.Command, args []string) error {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+
+	for _, token := range args {
+		if err := mock_auth.ExpireToken(db, token); err != nil {
+			return fmt.Errorf("Failed to expire token %v: %v", token, err)
+		}
+		fmt.Printf("Token %v has been expired\n", token)
+	}
+	return nil
+}
+
+func mockTokenRevokeRun(cmd *cobra.Command, args []string) error {
+	db, err := database.NewConnection(false)
+	if err != nil {
+		return err
+	}
+	defer db.DB.Close()
+
+	for _, token := range args {
+		if err := mock_auth.RevokeToken(db, token); err != nil {
+			return fmt.Errorf("Failed to revoke token %v: %v", token, err)
+		}
+		fmt.Printf("Token %v has been revoked\n", token)
+	}
+	return nil
+}
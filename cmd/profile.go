@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manages config profiles, each with its own credentials, token, API URLs and event settings.",
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all config profiles. The profile in use is marked with *.",
+	Args:  cobra.NoArgs,
+	RunE:  profileListCmdRun,
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:     "use <profile>",
+	Short:   "Selects the profile used when --profile and TWITCH_PROFILE aren't set. Create profiles with `twitch configure --profile <profile>`.",
+	Example: `twitch profile use mock`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    profileUseCmdRun,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd, profileUseCmd)
+}
+
+func profileListCmdRun(cmd *cobra.Command, args []string) error {
+	profiles, err := util.GetProfiles()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tPROFILE\tCLIENT ID\tBASE URL")
+	for _, p := range profiles {
+		config, err := readProfileConfig(p)
+		if err != nil {
+			return err
+		}
+
+		active := ""
+		if p == util.GetProfile() {
+			active = "*"
+		}
+
+		baseURL := config.GetString("BASE_URL")
+		if baseURL == "" {
+			baseURL = "(production)"
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", active, p, config.GetString("clientId"), baseURL)
+	}
+	return w.Flush()
+}
+
+func profileUseCmdRun(cmd *cobra.Command, args []string) error {
+	profile := args[0]
+	if err := util.SetProfile(profile); err != nil {
+		return err
+	}
+
+	path, err := util.GetConfigPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) && profile != util.DefaultProfile {
+		return fmt.Errorf("Profile %v does not exist. Create it with `twitch configure --profile %v`", profile, profile)
+	}
+
+	// the selected profile is stored in the default profile's config
+	config, err := readProfileConfig(util.DefaultProfile)
+	if err != nil {
+		return err
+	}
+	config.Set("profile", profile)
+
+	defaultPath, err := util.GetProfileConfigPath(util.DefaultProfile)
+	if err != nil {
+		return err
+	}
+	if err := config.WriteConfigAs(defaultPath); err != nil {
+		return fmt.Errorf("Failed to write configuration: %v", err.Error())
+	}
+
+	fmt.Printf("Now using profile %v.\n", profile)
+	return nil
+}
+
+// readProfileConfig reads a profile's config file into its own viper instance, so the config in use isn't changed
+func readProfileConfig(profile string) (*viper.Viper, error) {
+	path, err := util.GetProfileConfigPath(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("env")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return v, nil
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -14,6 +14,7 @@ import (
 )
 
 var cfgFile string
+var profileFlag string
 
 var rootCmd = &cobra.Command{
 	Use:   "twitch",
@@ -46,6 +47,7 @@ func init() {
 	rootCmd.SilenceErrors = true
 	rootCmd.SilenceUsage = true
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", fmt.Sprintf("config file (default is %s)", cfgFile))
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use. Defaults to the TWITCH_PROFILE environment variable, or the profile selected with `twitch profile use`.")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -72,7 +74,43 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err != nil {
+	viper.ReadInConfig()
+
+	// --config points at a specific file, so profiles don't apply
+	if cfgFile != "" {
 		return
 	}
+
+	profile := profileFlag
+	if profile == "" {
+		profile = viper.GetString("profile")
+	}
+	if profile == "" || profile == util.DefaultProfile {
+		return
+	}
+
+	if err := useProfile(profile); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// useProfile makes profile the active profile and replaces the config with its own
+func useProfile(profile string) error {
+	if err := util.SetProfile(profile); err != nil {
+		return err
+	}
+	profilePath, err := util.GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	// start from an empty config so values from the default profile don't leak into this one
+	viper.Reset()
+	viper.SetConfigFile(profilePath)
+	viper.SetConfigType("env")
+	viper.SetEnvPrefix("twitch")
+	viper.AutomaticEnv()
+	viper.ReadInConfig()
+	return nil
 }
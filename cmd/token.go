@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/twitchdev/twitch-cli/internal/extensions"
 	"github.com/twitchdev/twitch-cli/internal/login"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -26,6 +31,19 @@ var tokenServerPort int
 var tokenServerIP string
 var redirectHost string
 var useDeviceCodeFlow bool
+var authBaseURL string
+
+var extensionSecret string
+var extensionRole string
+var extensionChannelID string
+var extensionUserID string
+var extensionOpaqueUserID string
+var extensionExpiration time.Duration
+var extensionListenPerms []string
+var extensionSendPerms []string
+var extensionVerifyToken string
+
+var scopesTokenType string
 
 // loginCmd represents the login command
 var loginCmd = &cobra.Command{
@@ -34,6 +52,35 @@ var loginCmd = &cobra.Command{
 	RunE:  loginCmdRun,
 }
 
+var extensionJWTCmd = &cobra.Command{
+	Use:   "extension-jwt",
+	Short: "Signs or verifies an Extension JWT using the extension secret from the configuration or the --secret flag.",
+	Example: `twitch token extension-jwt --role broadcaster --channel-id 1234 --user-id 1234
+twitch token extension-jwt --verify eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...`,
+	RunE: extensionJWTCmdRun,
+}
+
+var scopesCmd = &cobra.Command{
+	Use:   "scopes",
+	Short: "Lists and explains the OAuth scopes known to the CLI. Works offline.",
+}
+
+var scopesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "Lists all known scopes.",
+	Example: `twitch token scopes list --token-type app`,
+	Args:    cobra.NoArgs,
+	RunE:    scopesListCmdRun,
+}
+
+var scopesExplainCmd = &cobra.Command{
+	Use:     "explain <scope>...",
+	Short:   "Describes one or more scopes, including which token types can use them and which mock API endpoints require them.",
+	Example: `twitch token scopes explain moderator:read:followers user:read:email`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    scopesExplainCmdRun,
+}
+
 func init() {
 	rootCmd.AddCommand(loginCmd)
 
@@ -48,9 +95,29 @@ func init() {
 	loginCmd.Flags().IntVarP(&tokenServerPort, "port", "p", 3000, "Manually set the port to be used for the User Token web server.")
 	loginCmd.Flags().StringVar(&redirectHost, "redirect-host", "localhost", "Manually set the host to be used for the redirect URL")
 	loginCmd.Flags().BoolVar(&useDeviceCodeFlow, "dcf", false, "Uses Device Code Flow for your User Access Token. Can only be used with --user-token")
+	loginCmd.Flags().StringVar(&authBaseURL, "auth-url", "", "Override the base URL of the OAuth server, e.g. http://localhost:8080/auth to log in against the mock API. Defaults to AUTH_BASE_URL from the CLI config, or the production Twitch OAuth server.")
+
+	loginCmd.AddCommand(extensionJWTCmd, scopesCmd)
+	scopesCmd.AddCommand(scopesListCmd, scopesExplainCmd)
+
+	scopesListCmd.Flags().StringVar(&scopesTokenType, "token-type", "", "Only list scopes that can be requested for this token type. Valid values are: app, user")
+
+	extensionJWTCmd.Flags().StringVar(&extensionSecret, "secret", "", "Base64 encoded extension secret. By default the extensionSecret value from CLI config will be used.")
+	extensionJWTCmd.Flags().StringVar(&extensionRole, "role", extensions.RoleExternal, fmt.Sprintf("Role of the JWT. Valid values are: %v", strings.Join(extensions.ValidRoles, ", ")))
+	extensionJWTCmd.Flags().StringVar(&extensionChannelID, "channel-id", "", "Channel ID the JWT is issued for.")
+	extensionJWTCmd.Flags().StringVar(&extensionUserID, "user-id", "", "Twitch user ID. For the external role this is the extension owner's user ID.")
+	extensionJWTCmd.Flags().StringVar(&extensionOpaqueUserID, "opaque-user-id", "", "Opaque user ID. Defaults to U<user-id>, or an anonymous ID if no user ID is set. Ignored for the external role.")
+	extensionJWTCmd.Flags().DurationVar(&extensionExpiration, "expiration", 3*time.Minute, "How long the JWT is valid for.")
+	extensionJWTCmd.Flags().StringSliceVar(&extensionListenPerms, "listen", nil, "PubSub targets the JWT may listen to. Defaults depend on the role.")
+	extensionJWTCmd.Flags().StringSliceVar(&extensionSendPerms, "send", nil, "PubSub targets the JWT may send to. Defaults depend on the role.")
+	extensionJWTCmd.Flags().StringVar(&extensionVerifyToken, "verify", "", "Instead of signing a new JWT, decode and verify the one passed to this parameter.")
 }
 
 func loginCmdRun(cmd *cobra.Command, args []string) error {
+	if err := secrets.LoadIntoConfig(); err != nil {
+		return err
+	}
+
 	clientID = viper.GetString("clientId")
 	clientSecret = viper.GetString("clientSecret")
 
@@ -76,17 +143,21 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 		clientSecret = overrideClientSecret
 	}
 
+	if authBaseURL == "" {
+		authBaseURL = viper.GetString("AUTH_BASE_URL")
+	}
+
 	var p = login.LoginParameters{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		Scopes:       userScopes,
 		RedirectURL:  redirectURL,
-		AuthorizeURL: login.UserAuthorizeURL,
+		AuthorizeURL: login.WithBaseURL(login.UserAuthorizeURL, authBaseURL),
 	}
 
 	if revokeToken != "" {
 		p.Token = revokeToken
-		p.URL = login.RevokeTokenURL
+		p.URL = login.WithBaseURL(login.RevokeTokenURL, authBaseURL)
 		_, err := login.CredentialsLogout(p)
 
 		if err != nil {
@@ -97,7 +168,7 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 
 	} else if validateToken != "" {
 		p.Token = validateToken
-		p.URL = login.ValidateTokenURL
+		p.URL = login.WithBaseURL(login.ValidateTokenURL, authBaseURL)
 		r, err := login.ValidateCredentials(p)
 		if err != nil {
 			return err
@@ -131,7 +202,7 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 		}
 
 	} else if refreshToken != "" {
-		p.URL = login.RefreshTokenURL
+		p.URL = login.WithBaseURL(login.RefreshTokenURL, authBaseURL)
 
 		// If we are overriding the Client ID then we shouldn't store this in the config.
 		shouldStoreInConfig := (overrideClientId == "")
@@ -140,7 +211,7 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 			RefreshToken: refreshToken,
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
-			URL:          login.RefreshTokenURL,
+			URL:          p.URL,
 		}, shouldStoreInConfig)
 
 		if err != nil {
@@ -166,9 +237,11 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 		var err error
 
 		if useDeviceCodeFlow {
+			p.URL = login.WithBaseURL(login.DeviceCodeFlowTokenURL, authBaseURL)
+			p.DeviceURL = login.WithBaseURL(login.DeviceCodeFlowUrl, authBaseURL)
 			resp, err = login.UserCredentialsLogin_DeviceCodeFlow(p)
 		} else {
-			p.URL = login.UserCredentialsURL
+			p.URL = login.WithBaseURL(login.UserCredentialsURL, authBaseURL)
 			resp, err = login.UserCredentialsLogin_AuthorizationCodeFlow(p, tokenServerIP, webserverPort)
 		}
 
@@ -185,7 +258,7 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 		log.Println(lightYellow("Scopes: ") + fmt.Sprintf("%v", resp.Response.Scope))
 
 	} else {
-		p.URL = login.ClientCredentialsURL
+		p.URL = login.WithBaseURL(login.ClientCredentialsURL, authBaseURL)
 		resp, err := login.ClientCredentialsLogin(p)
 
 		if err != nil {
@@ -202,3 +275,135 @@ func loginCmdRun(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func extensionJWTCmdRun(cmd *cobra.Command, args []string) error {
+	secret := viper.GetString("extensionSecret")
+	if extensionSecret != "" {
+		secret = extensionSecret
+	}
+
+	if secret == "" {
+		return fmt.Errorf("No extension secret found. Set extensionSecret in your configuration or use the --secret flag.")
+	}
+
+	lightYellow := color.New(color.FgHiYellow).PrintfFunc()
+	white := color.New(color.FgWhite).SprintfFunc()
+
+	var claims extensions.ExtensionClaims
+	if extensionVerifyToken != "" {
+		c, err := extensions.Verify(extensionVerifyToken, secret)
+		if err != nil {
+			return fmt.Errorf("JWT is invalid: %v", err)
+		}
+		claims = c
+
+		lightYellow("JWT is valid.\n")
+	} else {
+		token, c, err := extensions.SignWithParameters(extensions.SignParameters{
+			Secret:       secret,
+			Role:         extensionRole,
+			ChannelID:    extensionChannelID,
+			UserID:       extensionUserID,
+			OpaqueUserID: extensionOpaqueUserID,
+			ExpiresIn:    extensionExpiration,
+			Listen:       extensionListenPerms,
+			Send:         extensionSendPerms,
+		})
+		if err != nil {
+			return err
+		}
+		claims = c
+
+		lightYellow("JWT: %v\n", white(token))
+	}
+
+	lightYellow("Role: %v\n", white(claims.Role))
+	if claims.ChannelID != "" {
+		lightYellow("Channel ID: %v\n", white(claims.ChannelID))
+	}
+	if claims.UserID != "" {
+		lightYellow("User ID: %v\n", white(claims.UserID))
+	}
+	if claims.OpaqueUserID != "" {
+		lightYellow("Opaque User ID: %v\n", white(claims.OpaqueUserID))
+	}
+	lightYellow("Expires At: %v\n", white(time.Unix(claims.Expiration, 0).UTC().Format(time.RFC1123)))
+	lightYellow("PubSub Listen: %v\n", white("%v", claims.PubSubPerms.Listen))
+	lightYellow("PubSub Send: %v\n", white("%v", claims.PubSubPerms.Send))
+
+	return nil
+}
+
+func scopesListCmdRun(cmd *cobra.Command, args []string) error {
+	tokenType := ""
+	switch scopesTokenType {
+	case "":
+	case "app":
+		tokenType = scopes.AppAccessToken
+	case "user":
+		tokenType = scopes.UserAccessToken
+	default:
+		return fmt.Errorf("Invalid token type %v. Valid values are: app, user", scopesTokenType)
+	}
+
+	lightYellow := color.New(color.FgHiYellow).SprintfFunc()
+	white := color.New(color.FgWhite).SprintfFunc()
+
+	for _, s := range scopes.All() {
+		if tokenType != "" && !s.AllowsTokenType(tokenType) {
+			continue
+		}
+
+		description := s.Description
+		if s.Deprecated {
+			description = "(deprecated) " + description
+		}
+		fmt.Printf("%v %v\n", lightYellow("%-36v", s.Name), white(description))
+	}
+
+	return nil
+}
+
+func scopesExplainCmdRun(cmd *cobra.Command, args []string) error {
+	lightYellow := color.New(color.FgHiYellow).PrintfFunc()
+	white := color.New(color.FgWhite).SprintfFunc()
+
+	required := endpoints.RequiredScopes()
+	for i, name := range args {
+		s, ok := scopes.Get(name)
+		if !ok {
+			return fmt.Errorf("Unknown scope %v. Run `twitch token scopes list` to see all known scopes.", name)
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+
+		tokenTypes := []string{}
+		for _, t := range s.TokenTypes {
+			if t == scopes.AppAccessToken {
+				tokenTypes = append(tokenTypes, "App Access Token")
+			} else {
+				tokenTypes = append(tokenTypes, "User Access Token")
+			}
+		}
+
+		lightYellow("Scope: %v\n", white(s.Name))
+		lightYellow("Description: %v\n", white(s.Description))
+		lightYellow("Token Types: %v\n", white(strings.Join(tokenTypes, ", ")))
+		if s.Deprecated {
+			lightYellow("Deprecated: %v\n", white("true"))
+		}
+
+		if len(required[s.Name]) == 0 {
+			lightYellow("Required By: %v\n", white("No mock API endpoints"))
+		} else {
+			lightYellow("Required By:\n")
+			for _, e := range required[s.Name] {
+				fmt.Println(white("- %v", e))
+			}
+		}
+	}
+
+	return nil
+}
@@ -6,11 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"runtime"
-	"sort"
 	"strings"
 	"time"
 
@@ -18,20 +18,60 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/models"
 	"github.com/twitchdev/twitch-cli/internal/util"
 
-	"github.com/TylerBrock/colorjson"
-	"github.com/fatih/color"
 	"github.com/spf13/viper"
 )
 
-var baseURL = "https://api.twitch.tv/helix"
+// productionBaseURL is used unless BASE_URL is set, e.g. to the mock API
+const productionBaseURL = "https://api.twitch.tv/helix"
+
+var baseURL = productionBaseURL
 
 type clientInformation struct {
 	ClientID string
 	Token    string
 }
 
+// RequestParameters describe a request made with NewRequest and how its response is printed
+type RequestParameters struct {
+	Method          string
+	Path            string
+	QueryParameters []string
+	Body            []byte
+	PrettyPrint     bool
+	Autopaginate    *int // nil to not paginate; 0 to paginate until the last page
+	Verbose         bool
+	Output          string        // one of OutputFormats; defaults to OutputJSON
+	Fields          string        // comma separated jq-style paths selected from each item in data[]
+	ResumeFile      string        // when autopaginating, the cursor for the next page is saved here after every page
+	Resume          bool          // continue autopaginating from the cursor saved in ResumeFile
+	SkipValidation  bool          // send the request without checking it against the endpoint catalog
+	Export          string        // one of ExportFormats to print the request instead of sending it
+	HARFile         string        // every request and response is appended to this HAR archive
+	IncludeToken    bool          // include the token in exported requests and HAR archives rather than redacting it
+	Cache           bool          // serve GET requests from the response cache, and store their responses in it
+	RefreshCache    bool          // with Cache, ignore cached responses but still store the new ones
+	CacheTTL        time.Duration // with Cache, how long responses are cached for; 0 uses the TTL of the path
+}
+
+// rateLimitRetries is how many times a request is retried after a 429, once the rate limit resets
+const rateLimitRetries = 3
+
 // NewRequest is used to request data from the Twitch API using a HTTP GET request- this function is a wrapper for the apiRequest function that handles the network call
-func NewRequest(method string, path string, queryParameters []string, body []byte, prettyPrint bool, autopaginate *int, verbose bool) error {
+func NewRequest(p RequestParameters) error {
+	var har *harRecorder
+	if p.HARFile != "" {
+		har = newHARRecorder(p.IncludeToken)
+	}
+
+	err := newRequest(p, har)
+	// requests are saved even if they failed, so they can be attached to bug reports
+	if harErr := har.save(p.HARFile); harErr != nil && err == nil {
+		return fmt.Errorf("Error saving HAR archive: %v", harErr)
+	}
+	return err
+}
+
+func newRequest(p RequestParameters, har *harRecorder) error {
 	var data models.APIResponse
 	var err error
 	var cursor string
@@ -45,63 +85,126 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 
 	isExtensionsLiveEndpoint := false // https://github.com/twitchdev/twitch-cli/issues/157
 
+	output := p.Output
+	if output == "" {
+		output = OutputJSON
+	}
+	if !isOutputFormat(output) {
+		return fmt.Errorf("Invalid output format %v. Valid formats are: %v", output, strings.Join(OutputFormats, ", "))
+	}
+	fields, err := parseFields(p.Fields)
+	if err != nil {
+		return err
+	}
+	if p.Export != "" && p.Export != ExportCurl {
+		return fmt.Errorf("Invalid export format %v. Valid formats are: %v", p.Export, strings.Join(ExportFormats, ", "))
+	}
+
+	if !p.SkipValidation {
+		if err := ValidateRequest(p.Method, p.Path, parseQueryParameters(p.QueryParameters), p.Body); err != nil {
+			return err
+		}
+	}
+
 	data.Data = make([]interface{}, 0)
 	client, err := GetClientInformation()
 	if err != nil {
 		return fmt.Errorf("Error fetching client information: %v", err.Error())
 	}
 
-	if autopaginate != nil && *autopaginate < 0 {
+	if p.Autopaginate != nil && *p.Autopaginate < 0 {
 		return fmt.Errorf("Invalid pagination value provided. Must be greater than or equal to 0.")
 	}
 
-	if viper.GetString("BASE_URL") != "" {
-		baseURL = viper.GetString("BASE_URL")
+	if p.Resume && p.ResumeFile == "" {
+		return fmt.Errorf("A resume file is required to resume")
+	}
+	if p.ResumeFile != "" && p.Autopaginate == nil {
+		return fmt.Errorf("A resume file can only be used with autopagination")
 	}
 
-	runCounter := 1
-	for {
-		var apiResponse models.APIResponse
-
-		u, err := url.Parse(baseURL + path)
+	resume := resumeState{Method: p.Method, Path: p.Path, QueryParameters: p.QueryParameters}
+	if p.Resume {
+		resume, err = readResumeFile(p.ResumeFile, p)
 		if err != nil {
-			return fmt.Errorf("Error getting url: %v", err)
+			return err
 		}
+		cursor = resume.Cursor
+	}
 
-		q := u.Query()
-		for _, paramStr := range queryParameters {
-			var value string
-			param := strings.Split(paramStr, "=")
-			if len(param) == 2 {
-				value = param[1]
-			}
-			q.Add(param[0], value)
+	// pages are printed as they arrive, rather than once every page has been fetched; tables and YAML need every page first
+	streaming := output == OutputNDJSON || (p.Autopaginate != nil && (output == OutputJSON || output == OutputCSV) &&
+		!strings.Contains(p.Path, "schedule") && !strings.Contains(p.Path, "extensions/live"))
+	pages := newPageWriter(os.Stdout, output, p.PrettyPrint, fields)
+	streamed := false
+	// whether autopagination stopped early, either at the page limit or on an error, and can be resumed from cursor
+	stoppedEarly := false
+	defer func() {
+		if streamed {
+			pages.close(cursor)
 		}
+	}()
 
-		if cursor != "" {
-			q.Set("after", cursor)
-		}
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
 
-		if autopaginate != nil {
-			first := "100"
-			// since channel points custom rewards endpoints only support 50, capping that here
-			if strings.Contains(u.String(), "custom_rewards") {
-				first = "50"
-			}
+	if p.Export == ExportCurl {
+		u, err := requestURL(p, cursor)
+		if err != nil {
+			return err
+		}
+		fmt.Println(curlCommand(strings.ToUpper(p.Method), u.String(), p.Body, client, p.IncludeToken))
+		return nil
+	}
 
-			q.Set("first", first)
+	var cache *responseCache
+	if p.Cache && strings.ToUpper(p.Method) == http.MethodGet {
+		cache, err = newResponseCache(p)
+		if err != nil {
+			return fmt.Errorf("Error opening response cache: %v", err)
 		}
+	}
 
-		u.RawQuery = q.Encode()
+	runCounter := 1
+	refreshed := false
+	retries := 0
+	for {
+		var apiResponse models.APIResponse
 
-		resp, err := apiRequest(strings.ToUpper(method), u.String(), body, apiRequestParameters{
+		u, err := requestURL(p, cursor)
+		if err != nil {
+			return err
+		}
+
+		resp, err := apiRequest(strings.ToUpper(p.Method), u.String(), p.Body, apiRequestParameters{
 			ClientID: client.ClientID,
 			Token:    client.Token,
+			HAR:      har,
+			Cache:    cache,
 		})
 		if err != nil {
 			return fmt.Errorf("Error reading body: %v", err)
 		}
 
+		// the token may have been revoked or invalidated before it expired; refresh it once and replay the same page
+		if resp.StatusCode == http.StatusUnauthorized && !refreshed && isInvalidTokenResponse(resp.Body) {
+			refreshed = true
+			client, err = refreshClientInformation(client.ClientID)
+			if err != nil {
+				return fmt.Errorf("Error refreshing token: %v", err.Error())
+			}
+			continue
+		}
+		refreshed = false
+
+		// the client waits for the rate limit to reset before sending the request again
+		if resp.StatusCode == http.StatusTooManyRequests && retries < rateLimitRetries {
+			retries++
+			continue
+		}
+		retries = 0
+
 		if resp.StatusCode == http.StatusNoContent {
 			return fmt.Errorf("Endpoint responded with status 204")
 		}
@@ -149,6 +252,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 
 		if resp.StatusCode > 299 || resp.StatusCode < 200 {
 			data = apiResponse
+			stoppedEarly = true
 			break
 		}
 
@@ -156,10 +260,20 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 			break
 		}
 
-		if strings.Contains(path, "schedule") || data.Data == nil {
+		if streaming {
+			if p.Verbose && !streamed {
+				printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
+			}
+			streamed = true
+			if err := pages.writePage(selectFields(dataItems(apiResponse.Data), fields)); err != nil {
+				return err
+			}
+		}
+
+		if strings.Contains(p.Path, "schedule") || data.Data == nil {
 			data.Data = apiResponse.Data
 			break // autopagination unsupported
-		} else if runCounter > 1 {
+		} else if runCounter > 1 && !streaming {
 			data.Data = append(data.Data.([]interface{}), apiResponse.Data.([]interface{})...)
 		}
 
@@ -167,7 +281,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 			break
 		}
 
-		if autopaginate == nil {
+		if p.Autopaginate == nil {
 			data.Pagination = &models.APIPagination{
 				Cursor: apiResponse.Pagination.Cursor,
 			}
@@ -179,26 +293,71 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		}
 		cursor = apiResponse.Pagination.Cursor
 
+		if p.ResumeFile != "" {
+			resume.Cursor = cursor
+			resume.Pages++
+			if err := writeResumeFile(p.ResumeFile, resume); err != nil {
+				return err
+			}
+		}
+
 		// if autopaginate is 0, run indefinitely. otherwise, track counter and break once met limit
-		if *autopaginate != 0 && *autopaginate <= runCounter {
+		if *p.Autopaginate != 0 && *p.Autopaginate <= runCounter {
+			stoppedEarly = true
 			break // break if
 		}
 		runCounter++
 	}
 
+	// the resume file is only needed until the last page
+	if p.ResumeFile != "" && !stoppedEarly {
+		if err := removeResumeFile(p.ResumeFile); err != nil {
+			return err
+		}
+	}
+
+	if streamed {
+		next := ""
+		if stoppedEarly {
+			next = cursor
+		}
+		if err := pages.close(next); err != nil {
+			return err
+		}
+		streamed = false
+		if data.Error == "" {
+			return nil
+		}
+	}
+
 	if data.Data == nil {
 		data.Data = make([]interface{}, 0)
 	}
 	// handle json marshalling better; returns empty slice vs. null
 	_, isInterface := data.Data.([]interface{})
-	if isInterface && !strings.Contains(path, "schedule") && len(data.Data.([]interface{})) == 0 && data.Error == "" {
+	if isInterface && !strings.Contains(p.Path, "schedule") && len(data.Data.([]interface{})) == 0 && data.Error == "" {
 		data.Data = make([]interface{}, 0)
 	}
 	_, isStringMap := data.Data.(map[string]any)
-	if isStringMap && !strings.Contains(path, "schedule") && len(data.Data.(map[string]any)) == 0 && data.Error == "" {
+	if isStringMap && !strings.Contains(p.Path, "schedule") && len(data.Data.(map[string]any)) == 0 && data.Error == "" {
 		data.Data = make(map[string]any, 0)
 	}
 
+	if data.Error == "" && output != OutputJSON {
+		if p.Verbose {
+			printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
+		}
+		return writeOutput(os.Stdout, output, data, fields)
+	}
+
+	if data.Error == "" && len(fields) > 0 {
+		if _, isArray := data.Data.([]interface{}); isArray {
+			data.Data = selectFields(data.Data.([]interface{}), fields)
+		} else {
+			data.Data = selectFields(dataItems(data.Data), fields)[0]
+		}
+	}
+
 	var d []byte
 	if isExtensionsLiveEndpoint {
 		extensionBody := models.ExtensionAPIResponse{
@@ -219,14 +378,14 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		}
 	}
 
-	if prettyPrint {
+	if p.PrettyPrint {
 		var obj map[string]interface{}
 		json.Unmarshal(d, &obj)
 		// since Command Prompt/Powershell don't support coloring, will pretty print without colors
 		if runtime.GOOS == "windows" {
 			s, _ := json.MarshalIndent(obj, "", "  ")
 
-			if verbose {
+			if p.Verbose {
 				printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
 			}
 			if data.Error == "" {
@@ -238,15 +397,12 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 			return nil
 		}
 
-		f := colorjson.NewFormatter()
-		f.Indent = 2
-		f.KeyColor = color.New(color.FgBlue).Add(color.Bold)
-		s, err := f.Marshal(obj)
+		s, err := jsonFormatter().Marshal(obj)
 		if err != nil {
 			return err
 		}
 
-		if verbose {
+		if p.Verbose {
 			printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
 		}
 		if data.Error == "" {
@@ -257,7 +413,7 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 		return nil
 	}
 
-	if verbose {
+	if p.Verbose {
 		printVerboseHeaders(requestMethod, requestPath, requestHeaders, responseHeaders, responseStatusCode, protocol)
 	}
 	if data.Error == "" {
@@ -270,19 +426,76 @@ func NewRequest(method string, path string, queryParameters []string, body []byt
 	return nil
 }
 
-// ValidOptions returns a list of supported endpoints given a specified method as noted in the map endpointMethodSupports, which is located in resources.go of this package.
-func ValidOptions(method string) []string {
-	names := []string{}
+// requestURL returns the URL of a request, for the page after cursor when autopaginating
+func requestURL(p RequestParameters, cursor string) (*url.URL, error) {
+	u, err := url.Parse(baseURL + p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting url: %v", err)
+	}
+
+	q := u.Query()
+	for k, values := range parseQueryParameters(p.QueryParameters) {
+		for _, v := range values {
+			q.Add(k, v)
+		}
+	}
+
+	if cursor != "" {
+		q.Set("after", cursor)
+	}
 
-	for n, m := range endpointMethodSupports {
-		if m[method] {
-			names = append(names, n)
+	if p.Autopaginate != nil {
+		first := "100"
+		// since channel points custom rewards endpoints only support 50, capping that here
+		if strings.Contains(u.String(), "custom_rewards") {
+			first = "50"
 		}
+
+		q.Set("first", first)
 	}
 
-	sort.Strings(names)
+	u.RawQuery = q.Encode()
+	return u, nil
+}
 
-	return names
+// writeOutput prints a response in one of the non-JSON output formats
+func writeOutput(w io.Writer, output string, data models.APIResponse, fields []field) error {
+	items := selectFields(dataItems(data.Data), fields)
+
+	switch output {
+	case OutputNDJSON:
+		return writeNDJSON(w, items)
+	case OutputCSV:
+		return writeCSV(w, items, fields)
+	case OutputTable:
+		return writeTable(w, items, fields)
+	case OutputYAML:
+		// round trip through JSON, so keys match the JSON output
+		var obj interface{}
+		d, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("Error marshalling json: %v", err)
+		}
+		json.Unmarshal(d, &obj)
+		if m, ok := obj.(map[string]interface{}); ok && len(fields) > 0 {
+			if _, isArray := data.Data.([]interface{}); isArray {
+				m["data"] = items
+			} else {
+				m["data"] = items[0]
+			}
+		}
+		return writeYAML(w, obj)
+	}
+	return nil
+}
+
+func isOutputFormat(output string) bool {
+	for _, o := range OutputFormats {
+		if o == output {
+			return true
+		}
+	}
+	return false
 }
 
 func GetClientInformation() (clientInformation, error) {
@@ -300,28 +513,53 @@ func GetClientInformation() (clientInformation, error) {
 
 	ex, _ := time.Parse(time.RFC3339Nano, expiration)
 	if ex.Before(util.GetTimestamp()) {
-		refreshToken := viper.GetString("refreshToken")
+		return refreshClientInformation(clientID)
+	}
 
-		if refreshToken == "" {
-			log.Fatal("Please run twitch token")
-		}
+	return clientInformation{Token: token, ClientID: clientID}, nil
+}
+
+// refreshClientInformation gets a new token, refreshing user tokens and minting a new app token when there's no refresh token
+func refreshClientInformation(clientID string) (clientInformation, error) {
+	refreshToken := viper.GetString("refreshToken")
+	clientSecret := viper.GetString("clientSecret")
+	authBaseURL := viper.GetString("AUTH_BASE_URL")
 
-		clientSecret := viper.GetString("clientSecret")
+	if refreshToken == "" {
+		if clientSecret == "" {
+			return clientInformation{}, errors.New("No refresh token or Client Secret found. Please run `twitch configure` and `twitch token`")
+		}
 
-		var err error
-		r, err := login.RefreshUserToken(login.RefreshParameters{
-			RefreshToken: refreshToken,
+		r, err := login.ClientCredentialsLogin(login.LoginParameters{
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
-			URL:          login.RefreshTokenURL,
-		}, true)
+			URL:          login.WithBaseURL(login.ClientCredentialsURL, authBaseURL),
+		})
 		if err != nil {
 			return clientInformation{}, errors.New(err.Error() + "\nPlease rerun `twitch configure`")
 		}
-		token = r.Response.AccessToken
+		return clientInformation{Token: r.Response.AccessToken, ClientID: clientID}, nil
 	}
 
-	return clientInformation{Token: token, ClientID: clientID}, nil
+	r, err := login.RefreshUserToken(login.RefreshParameters{
+		RefreshToken: refreshToken,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		URL:          login.WithBaseURL(login.RefreshTokenURL, authBaseURL),
+	}, true)
+	if err != nil {
+		return clientInformation{}, errors.New(err.Error() + "\nPlease rerun `twitch configure`")
+	}
+	return clientInformation{Token: r.Response.AccessToken, ClientID: clientID}, nil
+}
+
+// isInvalidTokenResponse returns whether a 401 response body means the token itself was rejected, rather than e.g. a missing scope
+func isInvalidTokenResponse(body []byte) bool {
+	var r models.APIResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return false
+	}
+	return strings.EqualFold(r.Message, "Invalid OAuth token") || strings.EqualFold(r.Message, "Token expired")
 }
 
 func printVerboseHeaders(method string, path string, requestHeaders http.Header, responseHeaders http.Header, responseStatusCode int, protocol string) {
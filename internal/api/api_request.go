@@ -4,18 +4,21 @@ package api
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/request"
-	"golang.org/x/time/rate"
 )
 
+// apiClient is shared by every request, so the rate limit reported by one response applies to the next
+var apiClient = NewClient(nil)
+
 type apiRequestParameters struct {
 	Token    string
 	ClientID string
+	HAR      *harRecorder   // optional; records the request and its response
+	Cache    *responseCache // optional; serves GET requests from the cache and stores their responses
 }
 type apiRequestResponse struct {
 	StatusCode int
@@ -35,17 +38,30 @@ func apiRequest(method string, url string, payload []byte, p apiRequestParameter
 	}
 	req.Header.Set("Client-ID", p.ClientID)
 	req.Header.Set("Content-Type", "application/json")
-	rl := rate.NewLimiter(rate.Every(time.Minute), 800)
-
-	client := NewClient(rl)
-
 	if p.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+p.Token)
 	}
 
-	resp, err := client.Do(req)
+	// cached responses are used until they expire, then revalidated with their ETag if they have one
+	cacheable := p.Cache != nil && method == http.MethodGet
+	var cacheKey string
+	var cached cacheEntry
+	var hasCached bool
+	if cacheable {
+		cacheKey = p.Cache.key(method, url, p.ClientID)
+		cached, hasCached = p.Cache.get(cacheKey)
+		if hasCached && cached.fresh() {
+			return cached.response(req, "HIT"), nil
+		}
+		if hasCached && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+	}
+
+	started := time.Now()
+	resp, err := apiClient.Do(req)
 	if err != nil {
-		fmt.Printf("Error reading body: %v", err)
+		p.HAR.record(req, payload, nil, nil, started, time.Since(started))
 		return apiRequestResponse{}, err
 	}
 
@@ -53,11 +69,34 @@ func apiRequest(method string, url string, payload []byte, p apiRequestParameter
 
 	defer resp.Body.Close()
 
+	p.HAR.record(req, payload, resp, body, started, time.Since(started))
 	if err != nil {
-		fmt.Printf("Error reading body: %v", err)
 		return apiRequestResponse{}, err
 	}
 
+	if cacheable {
+		now := time.Now()
+		if resp.StatusCode == http.StatusNotModified && hasCached {
+			cached.ExpiresAt = now.Add(p.Cache.ttl)
+			// a response that can't be stored is still returned; it's only fetched again next time
+			p.Cache.put(cacheKey, cached)
+			return cached.response(req, "REVALIDATED"), nil
+		}
+		if resp.StatusCode == http.StatusOK {
+			p.Cache.put(cacheKey, cacheEntry{
+				Method:     method,
+				URL:        url,
+				StatusCode: resp.StatusCode,
+				Headers:    resp.Header,
+				Body:       string(body),
+				ETag:       resp.Header.Get("ETag"),
+				StoredAt:   now,
+				ExpiresAt:  now.Add(p.Cache.ttl),
+			})
+			resp.Header.Set(cacheStatusHeader, "MISS")
+		}
+	}
+
 	return apiRequestResponse{
 		StatusCode: resp.StatusCode,
 		Body:       body,
@@ -3,8 +3,11 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -56,27 +59,17 @@ func TestNewRequest(t *testing.T) {
 
 	defaultAutoPaginate := 0
 	// tests for normal get requests
-	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, true, nil, false)
-	NewRequest("GET", "", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
+	NewRequest(RequestParameters{Method: "GET", Path: "", QueryParameters: []string{"test=1", "test=2"}, PrettyPrint: true})
+	NewRequest(RequestParameters{Method: "GET", Path: "", QueryParameters: []string{"test=1", "test=2"}, Autopaginate: &defaultAutoPaginate})
 
 	// testing cursors autopagination
-	NewRequest("GET", "/cursor", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
+	NewRequest(RequestParameters{Method: "GET", Path: "/cursor", QueryParameters: []string{"test=1", "test=2"}, Autopaginate: &defaultAutoPaginate})
 
 	// testing 204 no-content apis
-	NewRequest("POST", "/nocontent", []string{"test=1", "test=2"}, nil, false, nil, false)
+	NewRequest(RequestParameters{Method: "POST", Path: "/nocontent", QueryParameters: []string{"test=1", "test=2"}})
 
 	// testing 500 errors
-	NewRequest("GET", "/error", []string{"test=1", "test=2"}, nil, false, &defaultAutoPaginate, false)
-}
-
-func TestValidOptions(t *testing.T) {
-	a := test_setup.SetupTestEnv(t)
-
-	get := ValidOptions("GET")
-	a.NotEmpty(get)
-
-	potato := ValidOptions("potato")
-	a.Empty(potato)
+	NewRequest(RequestParameters{Method: "GET", Path: "/error", QueryParameters: []string{"test=1", "test=2"}, Autopaginate: &defaultAutoPaginate})
 }
 
 func TestGetClientInformation(t *testing.T) {
@@ -104,3 +97,148 @@ func TestGetClientInformation(t *testing.T) {
 	clientInfo, err = GetClientInformation()
 	a.NotNil(err)
 }
+
+func TestNewRequestRefresh(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	validToken := ""
+	minted := 0
+	pages := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/auth/token") {
+			a.Equal("2222", r.URL.Query().Get("client_secret"))
+			if r.URL.Query().Get("grant_type") == "refresh_token" {
+				a.Equal("123", r.URL.Query().Get("refresh_token"))
+			}
+			minted++
+			validToken = fmt.Sprintf("token%v", minted)
+			w.Write([]byte(`{"access_token":"` + validToken + `","refresh_token":"` + r.URL.Query().Get("refresh_token") + `","expires_in":3600}`))
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+			return
+		}
+
+		// the token is revoked after the first page, so the second page is replayed after refreshing
+		pages = append(pages, r.URL.Query().Get("after"))
+		if r.URL.Query().Get("after") == "" {
+			validToken = "revoked"
+			w.Write([]byte(`{"data":[{"id":"1"}],"pagination":{"cursor":"page2"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"2"}],"pagination":{}}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("AUTH_BASE_URL", ts.URL+"/auth")
+	viper.Set("clientid", "1111")
+	viper.Set("clientsecret", "2222")
+	viper.Set("accesstoken", "old")
+	viper.Set("refreshtoken", "123")
+	viper.Set("tokenexpiration", "0")
+
+	// user token, refreshed before the first page and again mid-autopagination
+	autopaginate := 0
+	err := NewRequest(RequestParameters{Method: "GET", Path: "/users", Autopaginate: &autopaginate})
+	a.Nil(err)
+	a.Equal([]string{"", "page2"}, pages)
+	a.Equal(2, minted)
+	a.Equal("token2", viper.GetString("accesstoken"))
+
+	// app token, which is re-minted as there's no refresh token
+	pages = []string{}
+	viper.Set("accesstoken", "old")
+	viper.Set("refreshtoken", "")
+	viper.Set("tokenexpiration", "0")
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users"})
+	a.Nil(err)
+	a.Equal([]string{""}, pages)
+	a.Equal(3, minted)
+
+	// errors from refreshing are returned rather than the 401
+	viper.Set("clientsecret", "")
+	viper.Set("accesstoken", "old")
+	viper.Set("tokenexpiration", "0")
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users"})
+	a.NotNil(err)
+
+	viper.Set("BASE_URL", "")
+	viper.Set("AUTH_BASE_URL", "")
+}
+
+func TestNewRequestResume(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	sleep = func(d time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	failPage := "page3"
+	rateLimited := false
+	pages := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		if after == "page2" && !rateLimited {
+			rateLimited = true
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Too Many Requests","status":429,"message":""}`))
+			return
+		}
+		if after == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"Internal Server Error","status":500,"message":""}`))
+			return
+		}
+
+		pages = append(pages, after)
+		next := map[string]string{"": "page2", "page2": "page3", "page3": ""}[after]
+		w.Write([]byte(`{"data":[{"id":"` + after + `"}],"pagination":{"cursor":"` + next + `"}}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	defer viper.Set("BASE_URL", "")
+
+	resumeFile := filepath.Join(t.TempDir(), "resume.json")
+	autopaginate := 0
+	p := RequestParameters{Method: "get", Path: "/videos", QueryParameters: []string{"user_id=1"}, Autopaginate: &autopaginate, ResumeFile: resumeFile}
+
+	// the 429 is retried, then the third page fails and its cursor is kept
+	err := NewRequest(p)
+	a.NotNil(err)
+	a.Equal([]string{"", "page2"}, pages)
+
+	s, err := readResumeFile(resumeFile, p)
+	a.Nil(err)
+	a.Equal("page3", s.Cursor)
+	a.Equal(2, s.Pages)
+
+	// the resume file is only used for the same request
+	_, err = readResumeFile(resumeFile, RequestParameters{Method: "get", Path: "/videos", QueryParameters: []string{"user_id=2"}})
+	a.NotNil(err)
+
+	failPage = ""
+	pages = []string{}
+	p.Resume = true
+	err = NewRequest(p)
+	a.Nil(err)
+	a.Equal([]string{"page3"}, pages)
+
+	_, err = os.Stat(resumeFile)
+	a.True(os.IsNotExist(err))
+
+	// resuming a finished request fails, as there's nothing left to fetch
+	err = NewRequest(p)
+	a.NotNil(err)
+
+	p.Resume = false
+	p.Autopaginate = nil
+	err = NewRequest(p)
+	a.NotNil(err)
+}
@@ -0,0 +1,321 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// batchChunkSize is the number of values the chunked parameters of an endpoint accept, combined
+const batchChunkSize = 100
+
+// batchChunkParameters are the parameters of endpoints that accept up to batchChunkSize values, which batch requests are split on
+var batchChunkParameters = map[string][]string{
+	"/users":         {"id", "login"},
+	"/streams":       {"user_id", "user_login", "game_id"},
+	"/videos":        {"id"},
+	"/games":         {"id", "name", "igdb_id"},
+	"/channels":      {"broadcaster_id"},
+	"/clips":         {"id"},
+	"/chat/color":    {"user_id"},
+	"/subscriptions": {"user_id"},
+}
+
+// BatchQuery holds query parameters, which are given as a JSON object of strings, numbers, booleans or arrays of those
+type BatchQuery url.Values
+
+func (q *BatchQuery) UnmarshalJSON(b []byte) error {
+	var raw map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return err
+	}
+
+	*q = BatchQuery{}
+	for k, v := range raw {
+		values, ok := v.([]interface{})
+		if !ok {
+			values = []interface{}{v}
+		}
+		for _, value := range values {
+			switch value.(type) {
+			case string, json.Number, bool:
+				(*q)[k] = append((*q)[k], fmt.Sprint(value))
+			default:
+				return fmt.Errorf("Invalid value for query parameter %v", k)
+			}
+		}
+	}
+	return nil
+}
+
+// BatchRequest is one line of a batch file
+type BatchRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  BatchQuery      `json:"query"`
+	Body   json.RawMessage `json:"body"`
+
+	line int
+}
+
+// BatchResult is one line of batch output. Requests split into chunks have a result per chunk.
+type BatchResult struct {
+	Line     int             `json:"line"`
+	Chunk    int             `json:"chunk,omitempty"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Query    url.Values      `json:"query,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+type batchJob struct {
+	line    int
+	chunk   int
+	request BatchRequest
+	query   url.Values
+}
+
+// BatchParameters describe a batch of requests run with RunBatch
+type BatchParameters struct {
+	Input       io.Reader // NDJSON of BatchRequest
+	Output      io.Writer // NDJSON of BatchResult, written in the order requests complete
+	Concurrency int
+	// SkipValidation sends requests without checking them against the endpoint catalog; invalid requests otherwise fail without being sent
+	SkipValidation bool
+	HARFile        string // every request and response is appended to this HAR archive
+	IncludeToken   bool   // include the token in the HAR archive rather than redacting it
+}
+
+// ReadBatchRequests reads NDJSON request descriptors, skipping blank lines
+func ReadBatchRequests(r io.Reader) ([]BatchRequest, error) {
+	requests := []BatchRequest{}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	line := 0
+	for s.Scan() {
+		line++
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+
+		var req BatchRequest
+		if err := json.Unmarshal(s.Bytes(), &req); err != nil {
+			return nil, fmt.Errorf("Error reading request on line %v: %v", line, err)
+		}
+		req.Method = strings.ToUpper(req.Method)
+		if req.Method == "" {
+			req.Method = http.MethodGet
+		}
+		if req.Path == "" {
+			return nil, fmt.Errorf("Error reading request on line %v: missing path", line)
+		}
+		if !strings.HasPrefix(req.Path, "/") {
+			req.Path = "/" + req.Path
+		}
+		req.line = line
+		requests = append(requests, req)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// chunkBatchRequest splits a request into requests of at most batchChunkSize values for endpoints that accept lists of IDs or logins
+func chunkBatchRequest(req BatchRequest) []batchJob {
+	query := url.Values(req.Query)
+	if query == nil {
+		query = url.Values{}
+	}
+
+	type pair struct{ key, value string }
+	pairs := []pair{}
+	rest := url.Values{}
+	chunked := map[string]bool{}
+	for _, k := range batchChunkParameters[req.Path] {
+		chunked[k] = true
+		for _, v := range query[k] {
+			pairs = append(pairs, pair{k, v})
+		}
+	}
+	for k, v := range query {
+		if !chunked[k] {
+			rest[k] = v
+		}
+	}
+
+	if len(pairs) <= batchChunkSize {
+		return []batchJob{{line: req.line, request: req, query: query}}
+	}
+
+	jobs := []batchJob{}
+	for start := 0; start < len(pairs); start += batchChunkSize {
+		q := url.Values{}
+		for k, v := range rest {
+			q[k] = v
+		}
+		end := start + batchChunkSize
+		if end > len(pairs) {
+			end = len(pairs)
+		}
+		for _, p := range pairs[start:end] {
+			q.Add(p.key, p.value)
+		}
+		jobs = append(jobs, batchJob{line: req.line, chunk: len(jobs) + 1, request: req, query: q})
+	}
+	return jobs
+}
+
+// RunBatch runs every request in the input with bounded concurrency, writing a result for each as it completes.
+// An error is returned if any request failed or didn't return a 2xx status.
+func RunBatch(p BatchParameters) error {
+	var har *harRecorder
+	if p.HARFile != "" {
+		har = newHARRecorder(p.IncludeToken)
+	}
+
+	err := runBatch(p, har)
+	if harErr := har.save(p.HARFile); harErr != nil && err == nil {
+		return fmt.Errorf("Error saving HAR archive: %v", harErr)
+	}
+	return err
+}
+
+func runBatch(p BatchParameters, har *harRecorder) error {
+	requests, err := ReadBatchRequests(p.Input)
+	if err != nil {
+		return err
+	}
+
+	jobs := []batchJob{}
+	for _, req := range requests {
+		jobs = append(jobs, chunkBatchRequest(req)...)
+	}
+
+	client, err := GetClientInformation()
+	if err != nil {
+		return fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+
+	concurrency := p.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var mu sync.Mutex // guards client, failed and writing to the output
+	failed := 0
+	refreshed := false
+
+	queue := make(chan batchJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				result := BatchResult{Line: job.line, Chunk: job.chunk, Method: job.request.Method, Path: job.request.Path, Query: job.query}
+
+				u := baseURL + job.request.Path
+				if len(job.query) > 0 {
+					u += "?" + job.query.Encode()
+				}
+
+				if !p.SkipValidation {
+					if err := ValidateRequest(job.request.Method, job.request.Path, job.query, job.request.Body); err != nil {
+						result.Error = err.Error()
+					}
+				}
+
+				if result.Error == "" {
+					for retries := 0; ; {
+						mu.Lock()
+						c := client
+						mu.Unlock()
+
+						resp, err := apiRequest(job.request.Method, u, job.request.Body, apiRequestParameters{
+							ClientID: c.ClientID,
+							Token:    c.Token,
+							HAR:      har,
+						})
+						if err != nil {
+							result.Error = err.Error()
+							break
+						}
+
+						// the token is refreshed once for the whole batch; requests that used the old token are replayed
+						if resp.StatusCode == http.StatusUnauthorized && isInvalidTokenResponse(resp.Body) {
+							mu.Lock()
+							if client.Token == c.Token && !refreshed {
+								refreshed = true
+								var r clientInformation
+								if r, err = refreshClientInformation(c.ClientID); err == nil {
+									client = r
+								}
+							}
+							replay := client.Token != c.Token
+							mu.Unlock()
+							if err != nil {
+								result.Error = fmt.Sprintf("Error refreshing token: %v", err)
+								break
+							}
+							if replay {
+								continue
+							}
+						}
+
+						if resp.StatusCode == http.StatusTooManyRequests && retries < rateLimitRetries {
+							retries++
+							continue
+						}
+
+						result.Status = resp.StatusCode
+						if json.Valid(resp.Body) {
+							result.Response = resp.Body
+						} else if len(resp.Body) > 0 {
+							result.Response, _ = json.Marshal(string(resp.Body))
+						}
+						break
+					}
+				}
+
+				b, _ := json.Marshal(result)
+
+				mu.Lock()
+				if result.Error != "" || result.Status < 200 || result.Status > 299 {
+					failed++
+				}
+				fmt.Fprintln(p.Output, string(b))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%v of %v requests failed", failed, len(jobs))
+	}
+	return nil
+}
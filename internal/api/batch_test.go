@@ -0,0 +1,147 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestReadBatchRequests(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	requests, err := ReadBatchRequests(strings.NewReader(`{"path":"users","query":{"login":["a","b"],"first":10,"live":true}}
+
+{"method":"patch","path":"/channels","query":{"broadcaster_id":"1"},"body":{"title":"hi"}}
+`))
+	a.Nil(err)
+	a.Len(requests, 2)
+	a.Equal("GET", requests[0].Method)
+	a.Equal("/users", requests[0].Path)
+	a.Equal([]string{"a", "b"}, requests[0].Query["login"])
+	a.Equal([]string{"10"}, requests[0].Query["first"])
+	a.Equal([]string{"true"}, requests[0].Query["live"])
+	a.Equal(1, requests[0].line)
+	a.Equal("PATCH", requests[1].Method)
+	a.Equal(`{"title":"hi"}`, string(requests[1].Body))
+	a.Equal(3, requests[1].line)
+
+	_, err = ReadBatchRequests(strings.NewReader(`{"path":"/users"}` + "\n" + `{"method":"GET"}`))
+	a.ErrorContains(err, "line 2")
+
+	_, err = ReadBatchRequests(strings.NewReader(`{"path":"/users","query":{"id":{"a":1}}}`))
+	a.NotNil(err)
+}
+
+func TestChunkBatchRequest(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	req := BatchRequest{Method: "GET", Path: "/users", Query: BatchQuery{"first": {"1"}}, line: 4}
+	for i := 0; i < 150; i++ {
+		req.Query["id"] = append(req.Query["id"], fmt.Sprint(i))
+		req.Query["login"] = append(req.Query["login"], fmt.Sprintf("user%v", i))
+	}
+
+	jobs := chunkBatchRequest(req)
+	a.Len(jobs, 3)
+	a.Len(jobs[0].query["id"], 100)
+	a.Len(jobs[1].query["id"], 50)
+	a.Len(jobs[1].query["login"], 50)
+	a.Len(jobs[2].query["login"], 100)
+	for i, j := range jobs {
+		a.Equal(4, j.line)
+		a.Equal(i+1, j.chunk)
+		a.Equal([]string{"1"}, j.query["first"])
+	}
+
+	// endpoints without lists of IDs aren't chunked
+	req.Path = "/potato"
+	jobs = chunkBatchRequest(req)
+	a.Len(jobs, 1)
+	a.Equal(0, jobs[0].chunk)
+	a.Len(jobs[0].query["id"], 150)
+}
+
+func TestRunBatch(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var mu sync.Mutex
+	refreshes := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/auth/token") {
+			mu.Lock()
+			refreshes++
+			mu.Unlock()
+			w.Write([]byte(`{"access_token":"new","refresh_token":"123","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"Unauthorized","status":401,"message":"Invalid OAuth token"}`))
+			return
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("404 page not found"))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf(`{"data":[{"ids":%v}]}`, len(r.URL.Query()["id"]))))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("AUTH_BASE_URL", ts.URL+"/auth")
+	viper.Set("clientid", "1111")
+	viper.Set("clientsecret", "2222")
+	viper.Set("accesstoken", "old")
+	viper.Set("refreshtoken", "123")
+	viper.Set("tokenexpiration", "0")
+	defer viper.Set("BASE_URL", "")
+	defer viper.Set("AUTH_BASE_URL", "")
+
+	ids := []string{}
+	for i := 0; i < 120; i++ {
+		ids = append(ids, fmt.Sprintf(`"%v"`, i))
+	}
+	input := `{"path":"/users","query":{"id":[` + strings.Join(ids, ",") + `]}}
+{"path":"/streams"}
+{"path":"/missing"}
+{"method":"POST","path":"/streams/markers","body":{"user_id":"1"}}
+{"path":"/videos","query":{"potato":"1"}}
+`
+
+	var out bytes.Buffer
+	err := RunBatch(BatchParameters{Input: strings.NewReader(input), Output: &out, Concurrency: 3})
+	a.ErrorContains(err, "2 of 6 requests failed")
+	a.Equal(1, refreshes)
+
+	results := map[string]BatchResult{}
+	s := bufio.NewScanner(&out)
+	for s.Scan() {
+		var r BatchResult
+		a.Nil(json.Unmarshal(s.Bytes(), &r))
+		results[fmt.Sprintf("%v-%v", r.Line, r.Chunk)] = r
+	}
+	a.Len(results, 6)
+	a.JSONEq(`{"data":[{"ids":100}]}`, string(results["1-1"].Response))
+	a.JSONEq(`{"data":[{"ids":20}]}`, string(results["1-2"].Response))
+	a.Equal(200, results["2-0"].Status)
+	a.Equal(404, results["3-0"].Status)
+	a.JSONEq(`"404 page not found"`, string(results["3-0"].Response))
+	a.Equal("POST", results["4-0"].Method)
+	a.Equal(200, results["4-0"].Status)
+
+	// invalid requests aren't sent
+	a.Equal(0, results["5-0"].Status)
+	a.Contains(results["5-0"].Error, "unknown query parameter potato")
+}
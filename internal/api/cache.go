@@ -0,0 +1,218 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// cacheFolder is the folder in the application directory that cached responses are stored in
+const cacheFolder = "api-cache"
+
+// cacheStatusHeader is added to responses served from the cache, so --verbose shows where a response came from
+const cacheStatusHeader = "X-Twitch-Cli-Cache"
+
+// defaultCacheTTL is how long responses are cached for when their path isn't in cacheTTLs
+const defaultCacheTTL = time.Minute
+
+// cacheTTLs are how long responses are cached for by path, for the endpoints with slowly changing data
+var cacheTTLs = map[string]time.Duration{
+	"/bits/cheermotes":               time.Hour,
+	"/chat/badges":                   time.Hour,
+	"/chat/badges/global":            24 * time.Hour,
+	"/chat/emotes":                   time.Hour,
+	"/chat/emotes/global":            24 * time.Hour,
+	"/chat/emotes/set":               24 * time.Hour,
+	"/content_classification_labels": 24 * time.Hour,
+	"/games":                         24 * time.Hour,
+	"/games/top":                     10 * time.Minute,
+	"/teams":                         time.Hour,
+	"/users":                         10 * time.Minute,
+}
+
+// cacheTTL returns how long responses from path are cached for
+func cacheTTL(path string) time.Duration {
+	if ttl, ok := cacheTTLs["/"+strings.Trim(path, "/")]; ok {
+		return ttl
+	}
+	return defaultCacheTTL
+}
+
+// responseCache stores GET responses on disk, keyed by method, URL, client ID and user
+type responseCache struct {
+	dir     string
+	user    string
+	ttl     time.Duration
+	refresh bool // ignore cached responses, but store new ones
+}
+
+// cacheEntry is a cached response
+type cacheEntry struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+	ETag       string      `json:"etag,omitempty"`
+	StoredAt   time.Time   `json:"stored_at"`
+	ExpiresAt  time.Time   `json:"expires_at"`
+}
+
+func newResponseCache(p RequestParameters) (*responseCache, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	ttl := p.CacheTTL
+	if ttl <= 0 {
+		ttl = cacheTTL(p.Path)
+	}
+	user, err := cacheUser()
+	if err != nil {
+		return nil, err
+	}
+	return &responseCache{dir: dir, user: user, ttl: ttl, refresh: p.RefreshCache}, nil
+}
+
+func cacheDir() (string, error) {
+	home, err := util.GetApplicationDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, cacheFolder), nil
+}
+
+// cacheUser identifies who requests are made as, so profiles and users never see each other's responses.
+// User tokens are identified by their user's ID, which stays the same when the token is refreshed; app tokens have no user.
+func cacheUser() (string, error) {
+	user := "app"
+	if viper.GetString("refreshToken") != "" {
+		id, err := tokenUserID()
+		if err != nil {
+			return "", err
+		}
+		user = id
+	}
+	return util.GetProfile() + ":" + user, nil
+}
+
+// tokenUserID returns the ID of the user the token belongs to. It's looked up with /validate the first time
+// and stored in the config as tokenUserID, which is kept when the token is refreshed.
+func tokenUserID() (string, error) {
+	if id := viper.GetString("tokenUserID"); id != "" {
+		return id, nil
+	}
+
+	v, err := validateToken()
+	if err != nil {
+		return "", fmt.Errorf("Error looking up the token's user: %v", err)
+	}
+	if v.UserID == "" {
+		return "", errors.New("Error looking up the token's user: the token is invalid. Please run `twitch token`")
+	}
+
+	viper.Set("tokenUserID", v.UserID)
+	if err := secrets.WriteConfig(); err != nil {
+		return "", err
+	}
+	return v.UserID, nil
+}
+
+func (c *responseCache) key(method string, url string, clientID string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{method, url, clientID, c.user}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the response cached for key, whether or not it has expired
+func (c *responseCache) get(key string) (cacheEntry, bool) {
+	var e cacheEntry
+	if c == nil || c.refresh {
+		return e, false
+	}
+	b, err := os.ReadFile(filepath.Join(c.dir, key+".json"))
+	if err != nil {
+		return e, false
+	}
+	// unreadable entries are treated as missing and overwritten by the next response
+	if err := json.Unmarshal(b, &e); err != nil {
+		return e, false
+	}
+	return e, true
+}
+
+// put stores a response under key, replacing any previous one
+func (c *responseCache) put(key string, e cacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(c.dir, key+".json"))
+}
+
+// fresh returns whether the entry can be used without asking the API
+func (e cacheEntry) fresh() bool {
+	return time.Now().Before(e.ExpiresAt)
+}
+
+// response returns the cached response to req, marked with how it was served
+func (e cacheEntry) response(req *http.Request, status string) apiRequestResponse {
+	headers := e.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set(cacheStatusHeader, status)
+	return apiRequestResponse{
+		StatusCode: e.StatusCode,
+		Body:       []byte(e.Body),
+
+		HttpMethod:      req.Method,
+		RequestPath:     req.URL.RequestURI(),
+		RequestHeaders:  req.Header,
+		ResponseHeaders: headers,
+		HttpVersion:     req.Proto,
+	}
+}
+
+// ClearCache removes every cached response, for every profile, and returns how many were removed
+func ClearCache() (int, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return 0, err
+	}
+	entries, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
@@ -0,0 +1,208 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestCacheTTL(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal(24*time.Hour, cacheTTL("/games"))
+	a.Equal(24*time.Hour, cacheTTL("chat/emotes/global/"))
+	a.Equal(defaultCacheTTL, cacheTTL("/streams"))
+}
+
+func TestResponseCache(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	requests := 0
+	var ifNoneMatch string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ifNoneMatch = r.Header.Get("If-None-Match")
+		if r.URL.Query().Get("id") == "2" {
+			w.Write([]byte(`{"data":[{"id":"2"}]}`))
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"1"}]}`))
+	}))
+	defer ts.Close()
+
+	cache := &responseCache{dir: t.TempDir(), user: "default:app", ttl: time.Hour}
+	p := apiRequestParameters{ClientID: "1111", Token: "4567", Cache: cache}
+
+	resp, err := apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(1, requests)
+	a.Equal("MISS", resp.ResponseHeaders.Get(cacheStatusHeader))
+
+	resp, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(1, requests)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal(`{"data":[{"id":"1"}]}`, string(resp.Body))
+	a.Equal("HIT", resp.ResponseHeaders.Get(cacheStatusHeader))
+
+	// the URL, client ID and user are all part of the key
+	_, err = apiRequest(http.MethodGet, ts.URL+"/games?id=2", nil, p)
+	a.Nil(err)
+	a.Equal(2, requests)
+	_, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, apiRequestParameters{ClientID: "2222", Cache: cache})
+	a.Nil(err)
+	a.Equal(3, requests)
+	other := &responseCache{dir: cache.dir, user: "default:someone-else", ttl: time.Hour}
+	_, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, apiRequestParameters{ClientID: "1111", Cache: other})
+	a.Nil(err)
+	a.Equal(4, requests)
+
+	// only GET requests are cached
+	_, err = apiRequest(http.MethodPost, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	_, err = apiRequest(http.MethodPost, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(6, requests)
+
+	// expired responses are revalidated with their ETag
+	cache.ttl = -time.Second
+	cache.refresh = true
+	_, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	cache.ttl = time.Hour
+	cache.refresh = false
+	resp, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(8, requests)
+	a.Equal(`"v1"`, ifNoneMatch)
+	a.Equal(http.StatusOK, resp.StatusCode)
+	a.Equal(`{"data":[{"id":"1"}]}`, string(resp.Body))
+	a.Equal("REVALIDATED", resp.ResponseHeaders.Get(cacheStatusHeader))
+
+	// and are fresh again afterwards
+	_, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(8, requests)
+
+	// refreshing ignores the cached response, including its ETag
+	cache.refresh = true
+	resp, err = apiRequest(http.MethodGet, ts.URL+"/games?id=1", nil, p)
+	a.Nil(err)
+	a.Equal(9, requests)
+	a.Empty(ifNoneMatch)
+	a.Equal("MISS", resp.ResponseHeaders.Get(cacheStatusHeader))
+}
+
+func TestNewRequestCache(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":[{"id":"1"}]}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	viper.Set("refreshToken", "")
+	defer viper.Set("BASE_URL", "")
+
+	_, err := ClearCache()
+	a.Nil(err)
+
+	p := RequestParameters{Method: "get", Path: "/games", QueryParameters: []string{"id=1"}, Cache: true}
+	a.Nil(NewRequest(p))
+	a.Nil(NewRequest(p))
+	a.Equal(1, requests)
+
+	// requests made without the cache don't use it
+	p.Cache = false
+	a.Nil(NewRequest(p))
+	a.Equal(2, requests)
+
+	// a different user doesn't see the cached response
+	viper.Set("refreshToken", "abcd")
+	viper.Set("tokenUserID", "141981764")
+	defer viper.Set("refreshToken", "")
+	defer viper.Set("tokenUserID", "")
+	p.Cache = true
+	a.Nil(NewRequest(p))
+	a.Equal(3, requests)
+
+	removed, err := ClearCache()
+	a.Nil(err)
+	a.Equal(2, removed)
+	a.Nil(NewRequest(p))
+	a.Equal(4, requests)
+
+	_, err = ClearCache()
+	a.Nil(err)
+}
+
+// cached responses are kept for the token's user, so they're still used after the token is refreshed
+func TestCacheAfterRefresh(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	requests, validations := 0, 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/validate":
+			validations++
+			w.Write([]byte(`{"client_id":"1111","login":"twitchdev","user_id":"141981764","scopes":[],"expires_in":100}`))
+		case "/auth/token":
+			a.Equal("r1", r.URL.Query().Get("refresh_token"))
+			w.Write([]byte(`{"access_token":"t2","refresh_token":"r2","expires_in":3600,"scope":[],"token_type":"bearer"}`))
+		default:
+			requests++
+			w.Write([]byte(`{"data":[{"id":"1"}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("AUTH_BASE_URL", ts.URL+"/auth")
+	viper.Set("clientid", "1111")
+	viper.Set("clientsecret", "2222")
+	viper.Set("accesstoken", "t1")
+	viper.Set("refreshtoken", "r1")
+	viper.Set("tokenexpiration", "0")
+	viper.Set("tokenuserid", "")
+	defer func() {
+		for _, k := range []string{"BASE_URL", "AUTH_BASE_URL", "clientsecret", "refreshtoken", "tokenuserid"} {
+			viper.Set(k, "")
+		}
+	}()
+
+	_, err := ClearCache()
+	a.Nil(err)
+	defer ClearCache()
+
+	p := RequestParameters{Method: "get", Path: "/games", QueryParameters: []string{"id=1"}, Cache: true}
+	a.Nil(NewRequest(p))
+	a.Equal(1, requests)
+	a.Equal(1, validations)
+	a.Equal("141981764", viper.GetString("tokenUserID"))
+
+	_, err = refreshClientInformation("1111")
+	a.Nil(err)
+	a.Equal("r2", viper.GetString("refreshToken"))
+	viper.Set("tokenexpiration", "0")
+
+	a.Nil(NewRequest(p))
+	a.Equal(1, requests)
+	a.Equal(1, validations)
+}
@@ -0,0 +1,756 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+)
+
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+)
+
+// Endpoint describes a Helix endpoint, as documented at https://dev.twitch.tv/docs/api/reference
+type Endpoint struct {
+	Path       string                    `json:"path"`
+	Deprecated bool                      `json:"deprecated,omitempty"`
+	Methods    map[string]EndpointMethod `json:"methods"`
+}
+
+// EndpointMethod describes one method of an endpoint
+type EndpointMethod struct {
+	Description  string      `json:"description"`
+	Parameters   []Parameter `json:"parameters,omitempty"`
+	RequireOneOf []string    `json:"require_one_of,omitempty"` // at least one of these parameters is required
+	Body         []BodyField `json:"body,omitempty"`
+	Scopes       []string    `json:"scopes,omitempty"`      // one of these scopes is required
+	TokenTypes   []string    `json:"token_types,omitempty"` // either token type is accepted if empty
+	Paginated    bool        `json:"paginated"`
+}
+
+// Parameter is a query parameter of an endpoint method
+type Parameter struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+	Repeatable  bool   `json:"repeatable,omitempty"`
+	MaxCount    int    `json:"max_count,omitempty"` // maximum number of values for repeatable parameters
+}
+
+// BodyField is a top-level field of an endpoint method's JSON body
+type BodyField struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+var userToken = []string{scopes.UserAccessToken}
+var appToken = []string{scopes.AppAccessToken}
+
+func firstParam(max int) Parameter {
+	return Parameter{Name: "first", Type: TypeInteger, Description: fmt.Sprintf("Maximum number of items to return per page, up to %v.", max)}
+}
+
+var afterParam = Parameter{Name: "after", Type: TypeString, Description: "Cursor to get the next page of results."}
+var beforeParam = Parameter{Name: "before", Type: TypeString, Description: "Cursor to get the previous page of results."}
+
+// catalog lists the endpoints the CLI knows about, along with generatedCatalog. Requests to other paths are sent without validation.
+var catalog = []Endpoint{
+	{
+		Path: "/analytics/extensions",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets an analytics report for one or more extensions.",
+				Parameters: []Parameter{
+					{Name: "extension_id", Type: TypeString, Description: "ID of the extension to get a report for."},
+					{Name: "type", Type: TypeString, Description: "Type of report to get. Possible values: overview_v2."},
+					{Name: "started_at", Type: TypeString, Description: "RFC3339 date the report starts on."},
+					{Name: "ended_at", Type: TypeString, Description: "RFC3339 date the report ends on."},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"analytics:read:extensions"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/analytics/games",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets an analytics report for one or more games.",
+				Parameters: []Parameter{
+					{Name: "game_id", Type: TypeString, Description: "ID of the game to get a report for."},
+					{Name: "type", Type: TypeString, Description: "Type of report to get. Possible values: overview_v2."},
+					{Name: "started_at", Type: TypeString, Description: "RFC3339 date the report starts on."},
+					{Name: "ended_at", Type: TypeString, Description: "RFC3339 date the report ends on."},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"analytics:read:games"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/bits/cheermotes",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the Cheermotes that users can use to cheer Bits.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to include custom Cheermotes for."},
+				},
+			},
+		},
+	},
+	{
+		Path: "/bits/leaderboard",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the Bits leaderboard for the authenticated broadcaster.",
+				Parameters: []Parameter{
+					{Name: "count", Type: TypeInteger, Description: "Number of results to return, from 1 to 100."},
+					{Name: "period", Type: TypeString, Description: "Time period to get data for. Possible values: day, week, month, year, all."},
+					{Name: "started_at", Type: TypeString, Description: "RFC3339 start date of the period."},
+					{Name: "user_id", Type: TypeString, Description: "ID of a user to get results for."},
+				},
+				Scopes:     []string{"bits:read"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/channels",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets information about one or more channels.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of a broadcaster to get channel information for.", Required: true, Repeatable: true, MaxCount: 100},
+				},
+			},
+			"PATCH": {
+				Description: "Updates a channel's properties.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster whose channel to update.", Required: true},
+				},
+				Body: []BodyField{
+					{Name: "game_id", Type: TypeString, Description: "ID of the game being played."},
+					{Name: "broadcaster_language", Type: TypeString, Description: "ISO 639-1 code of the broadcast's language."},
+					{Name: "title", Type: TypeString, Description: "Title of the stream."},
+					{Name: "delay", Type: TypeInteger, Description: "Number of seconds to buffer the broadcast for. Partners only."},
+					{Name: "tags", Type: TypeArray, Description: "Tags to apply to the channel."},
+					{Name: "content_classification_labels", Type: TypeArray, Description: "Content classification labels to enable or disable."},
+					{Name: "is_branded_content", Type: TypeBoolean, Description: "Whether the channel has branded content."},
+				},
+				Scopes:     []string{"channel:manage:broadcast"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/channels/commercial",
+		Methods: map[string]EndpointMethod{
+			"POST": {
+				Description: "Starts a commercial on the broadcaster's channel.",
+				Body: []BodyField{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to run the commercial on.", Required: true},
+					{Name: "length", Type: TypeInteger, Description: "Length of the commercial in seconds, up to 180.", Required: true},
+				},
+				Scopes:     []string{"channel:edit:commercial"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/channels/followed",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the broadcasters a user follows.",
+				Parameters: []Parameter{
+					{Name: "user_id", Type: TypeString, Description: "ID of the user to get followed broadcasters for. Must match the user in the token.", Required: true},
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of a broadcaster to check whether the user follows."},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"user:read:follows"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/channels/followers",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the users that follow a broadcaster.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to get followers for.", Required: true},
+					{Name: "user_id", Type: TypeString, Description: "ID of a user to check whether they follow the broadcaster."},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"moderator:read:followers"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/clips",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets clips for a broadcaster or game, or by ID.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to get clips for."},
+					{Name: "game_id", Type: TypeString, Description: "ID of the game to get clips for."},
+					{Name: "id", Type: TypeString, Description: "ID of a clip to get.", Repeatable: true, MaxCount: 100},
+					{Name: "started_at", Type: TypeString, Description: "RFC3339 date to get clips created on or after."},
+					{Name: "ended_at", Type: TypeString, Description: "RFC3339 date to get clips created before."},
+					{Name: "is_featured", Type: TypeBoolean, Description: "Whether to only get featured clips."},
+					firstParam(100), beforeParam, afterParam,
+				},
+				RequireOneOf: []string{"broadcaster_id", "game_id", "id"},
+				Paginated:    true,
+			},
+			"POST": {
+				Description: "Creates a clip from the broadcaster's stream.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster whose stream to clip.", Required: true},
+					{Name: "has_delay", Type: TypeBoolean, Description: "Whether to add a delay before the clip is captured."},
+				},
+				Scopes:     []string{"clips:edit"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/entitlements/code",
+		Methods: map[string]EndpointMethod{
+			"POST": {
+				Description: "Redeems one or more redemption codes.",
+				Parameters: []Parameter{
+					{Name: "code", Type: TypeString, Description: "Redemption code to redeem.", Required: true, Repeatable: true, MaxCount: 20},
+					{Name: "user_id", Type: TypeString, Description: "ID of the user the codes are redeemed for."},
+				},
+				TokenTypes: appToken,
+			},
+		},
+	},
+	{
+		Path: "/entitlements/codes",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the status of one or more redemption codes.",
+				Parameters: []Parameter{
+					{Name: "code", Type: TypeString, Description: "Redemption code to check.", Required: true, Repeatable: true, MaxCount: 20},
+					{Name: "user_id", Type: TypeString, Description: "ID of the user the codes are checked for.", Required: true},
+				},
+				TokenTypes: appToken,
+			},
+		},
+	},
+	{
+		Path: "/entitlements/drops",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the Drops entitlements for an organization, game or user.",
+				Parameters: []Parameter{
+					{Name: "id", Type: TypeString, Description: "ID of an entitlement to get.", Repeatable: true, MaxCount: 100},
+					{Name: "user_id", Type: TypeString, Description: "ID of the user to get entitlements for."},
+					{Name: "game_id", Type: TypeString, Description: "ID of the game to get entitlements for."},
+					{Name: "fulfillment_status", Type: TypeString, Description: "Status of the entitlements to get. Possible values: CLAIMED, FULFILLED."},
+					firstParam(1000), afterParam,
+				},
+				Paginated: true,
+			},
+			"PATCH": {
+				Description: "Updates the fulfillment status of Drops entitlements.",
+				Body: []BodyField{
+					{Name: "entitlement_ids", Type: TypeArray, Description: "IDs of the entitlements to update, up to 100."},
+					{Name: "fulfillment_status", Type: TypeString, Description: "Status to set. Possible values: CLAIMED, FULFILLED."},
+				},
+			},
+		},
+	},
+	{
+		Path:       "/entitlements/upload",
+		Deprecated: true,
+		Methods: map[string]EndpointMethod{
+			"POST": {
+				Description: "Creates a URL to upload a manifest file to.",
+				Parameters: []Parameter{
+					{Name: "manifest_id", Type: TypeString, Description: "ID of the manifest to upload.", Required: true},
+					{Name: "type", Type: TypeString, Description: "Type of entitlement. Possible values: bulk_drops_grant.", Required: true},
+				},
+				TokenTypes: appToken,
+			},
+		},
+	},
+	{
+		Path: "/extensions/transactions",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets Bits in Extensions transactions for an extension.",
+				Parameters: []Parameter{
+					{Name: "extension_id", Type: TypeString, Description: "ID of the extension to get transactions for.", Required: true},
+					{Name: "id", Type: TypeString, Description: "ID of a transaction to get.", Repeatable: true, MaxCount: 100},
+					firstParam(100), afterParam,
+				},
+				TokenTypes: appToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/games",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets information about games or categories by ID or name.",
+				Parameters: []Parameter{
+					{Name: "id", Type: TypeString, Description: "ID of a game to get.", Repeatable: true, MaxCount: 100},
+					{Name: "name", Type: TypeString, Description: "Name of a game to get.", Repeatable: true, MaxCount: 100},
+					{Name: "igdb_id", Type: TypeString, Description: "IGDB ID of a game to get.", Repeatable: true, MaxCount: 100},
+				},
+				RequireOneOf: []string{"id", "name", "igdb_id"},
+			},
+		},
+	},
+	{
+		Path: "/games/top",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets games sorted by number of current viewers.",
+				Parameters:  []Parameter{firstParam(100), beforeParam, afterParam},
+				Paginated:   true,
+			},
+		},
+	},
+	{
+		Path: "/hypetrain/events",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the Hype Train events for a broadcaster.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to get events for. Must match the user in the token.", Required: true},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"channel:read:hype_train"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/moderation/banned",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the users banned from a broadcaster's channel.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to get banned users for.", Required: true},
+					{Name: "user_id", Type: TypeString, Description: "ID of a user to check whether they're banned.", Repeatable: true, MaxCount: 100},
+					firstParam(100), beforeParam, afterParam,
+				},
+				Scopes:     []string{"moderation:read"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path:       "/moderation/banned/events",
+		Deprecated: true,
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets ban and unban events for a broadcaster's channel.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster to get events for.", Required: true},
+					{Name: "user_id", Type: TypeString, Description: "ID of a user to get events for.", Repeatable: true, MaxCount: 100},
+					firstParam(100), afterParam,
+				},
+				Scopes:     []string{"moderation:read"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path: "/moderation/enforcements/status",
+		Methods: map[string]EndpointMethod{
+			"POST": {
+				Description: "Checks whether AutoMod would flag messages.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster whose AutoMod settings to check against.", Required: true},
+				},
+				Body: []BodyField{
+					{Name: "data", Type: TypeArray, Description: "Messages to check, each with a msg_id and msg_text.", Required: true},
+				},
+				Scopes:     []string{"moderation:read"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/search/categories",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the games or categories that match a query.",
+				Parameters: []Parameter{
+					{Name: "query", Type: TypeString, Description: "Text to search for.", Required: true},
+					firstParam(100), afterParam,
+				},
+				Paginated: true,
+			},
+		},
+	},
+	{
+		Path: "/search/channels",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the channels that match a query.",
+				Parameters: []Parameter{
+					{Name: "query", Type: TypeString, Description: "Text to search for.", Required: true},
+					{Name: "live_only", Type: TypeBoolean, Description: "Whether to only return channels that are live."},
+					firstParam(100), afterParam,
+				},
+				Paginated: true,
+			},
+		},
+	},
+	{
+		Path: "/streams",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets active streams, sorted by number of viewers.",
+				Parameters: []Parameter{
+					{Name: "user_id", Type: TypeString, Description: "ID of a broadcaster to get the stream of.", Repeatable: true, MaxCount: 100},
+					{Name: "user_login", Type: TypeString, Description: "Login of a broadcaster to get the stream of.", Repeatable: true, MaxCount: 100},
+					{Name: "game_id", Type: TypeString, Description: "ID of a game to get streams for.", Repeatable: true, MaxCount: 100},
+					{Name: "type", Type: TypeString, Description: "Type of stream. Possible values: all, live."},
+					{Name: "language", Type: TypeString, Description: "ISO 639-1 code of a language to get streams in.", Repeatable: true, MaxCount: 100},
+					firstParam(100), beforeParam, afterParam,
+				},
+				Paginated: true,
+			},
+		},
+	},
+	{
+		Path: "/streams/key",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets a broadcaster's stream key.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster. Must match the user in the token.", Required: true},
+				},
+				Scopes:     []string{"channel:read:stream_key"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/streams/markers",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the stream markers of a broadcaster's most recent stream or a video.",
+				Parameters: []Parameter{
+					{Name: "user_id", Type: TypeString, Description: "ID of the broadcaster to get markers from their most recent stream."},
+					{Name: "video_id", Type: TypeString, Description: "ID of the video to get markers from."},
+					firstParam(100), beforeParam, afterParam,
+				},
+				RequireOneOf: []string{"user_id", "video_id"},
+				Scopes:       []string{"user:read:broadcast", "channel:manage:broadcast"},
+				TokenTypes:   userToken,
+				Paginated:    true,
+			},
+			"POST": {
+				Description: "Adds a marker to a live stream.",
+				Body: []BodyField{
+					{Name: "user_id", Type: TypeString, Description: "ID of the broadcaster that's streaming.", Required: true},
+					{Name: "description", Type: TypeString, Description: "Description of the marker, up to 140 characters."},
+				},
+				Scopes:     []string{"channel:manage:broadcast"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path:       "/streams/tags",
+		Deprecated: true,
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the tags applied to a stream.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster.", Required: true},
+				},
+			},
+			"PUT": {
+				Description: "Replaces the tags applied to a stream.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster.", Required: true},
+				},
+				Body: []BodyField{
+					{Name: "tag_ids", Type: TypeArray, Description: "IDs of the tags to apply."},
+				},
+				Scopes:     []string{"channel:manage:broadcast"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/subscriptions",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the users subscribed to a broadcaster.",
+				Parameters: []Parameter{
+					{Name: "broadcaster_id", Type: TypeString, Description: "ID of the broadcaster. Must match the user in the token.", Required: true},
+					{Name: "user_id", Type: TypeString, Description: "ID of a user to check whether they're subscribed.", Repeatable: true, MaxCount: 100},
+					firstParam(100), beforeParam, afterParam,
+				},
+				Scopes:     []string{"channel:read:subscriptions"},
+				TokenTypes: userToken,
+				Paginated:  true,
+			},
+		},
+	},
+	{
+		Path:       "/tags/streams",
+		Deprecated: true,
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the stream tags defined by Twitch.",
+				Parameters: []Parameter{
+					{Name: "tag_id", Type: TypeString, Description: "ID of a tag to get.", Repeatable: true, MaxCount: 100},
+					firstParam(100), afterParam,
+				},
+				Paginated: true,
+			},
+		},
+	},
+	{
+		Path: "/users",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets information about users by ID or login, or the user in the token if neither is set.",
+				Parameters: []Parameter{
+					{Name: "id", Type: TypeString, Description: "ID of a user to get.", Repeatable: true, MaxCount: 100},
+					{Name: "login", Type: TypeString, Description: "Login of a user to get.", Repeatable: true, MaxCount: 100},
+				},
+			},
+			"PUT": {
+				Description: "Updates the description of the user in the token.",
+				Parameters: []Parameter{
+					{Name: "description", Type: TypeString, Description: "New description, up to 300 characters."},
+				},
+				Scopes:     []string{"user:edit"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/users/extensions",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets the active extensions a user has installed.",
+				Parameters: []Parameter{
+					{Name: "user_id", Type: TypeString, Description: "ID of the user to get extensions for. Defaults to the user in the token."},
+				},
+			},
+			"PUT": {
+				Description: "Updates the installed extensions of the user in the token.",
+				Body: []BodyField{
+					{Name: "data", Type: TypeObject, Description: "The panel, overlay and component extensions to activate.", Required: true},
+				},
+				Scopes:     []string{"user:edit:broadcast", "channel:manage:extensions"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+	{
+		Path: "/users/extensions/list",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets all the extensions the user in the token has installed.",
+				Scopes:      []string{"user:read:broadcast", "user:edit:broadcast"},
+				TokenTypes:  userToken,
+			},
+		},
+	},
+	{
+		Path: "/videos",
+		Methods: map[string]EndpointMethod{
+			"GET": {
+				Description: "Gets videos by ID, or the videos of a broadcaster or game.",
+				Parameters: []Parameter{
+					{Name: "id", Type: TypeString, Description: "ID of a video to get.", Repeatable: true, MaxCount: 100},
+					{Name: "user_id", Type: TypeString, Description: "ID of the broadcaster to get videos for."},
+					{Name: "game_id", Type: TypeString, Description: "ID of the game to get videos for."},
+					{Name: "language", Type: TypeString, Description: "ISO 639-1 code of the videos' language."},
+					{Name: "period", Type: TypeString, Description: "Time period the videos were published in. Possible values: all, day, month, week."},
+					{Name: "sort", Type: TypeString, Description: "Order to sort videos in. Possible values: time, trending, views."},
+					{Name: "type", Type: TypeString, Description: "Type of video. Possible values: all, archive, highlight, upload."},
+					firstParam(100), beforeParam, afterParam,
+				},
+				RequireOneOf: []string{"id", "user_id", "game_id"},
+				Paginated:    true,
+			},
+			"DELETE": {
+				Description: "Deletes one or more videos.",
+				Parameters: []Parameter{
+					{Name: "id", Type: TypeString, Description: "ID of a video to delete.", Required: true, Repeatable: true, MaxCount: 5},
+				},
+				Scopes:     []string{"channel:manage:videos"},
+				TokenTypes: userToken,
+			},
+		},
+	},
+}
+
+// GetEndpoint returns the catalog entry for a path, e.g. /users. Hand-written entries take precedence over generated ones.
+func GetEndpoint(path string) (Endpoint, bool) {
+	for _, e := range catalog {
+		if e.Path == path {
+			return e, true
+		}
+	}
+	for _, e := range generatedCatalog {
+		if e.Path == path {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// Endpoints returns every endpoint in the catalog, sorted by path
+func Endpoints() []Endpoint {
+	endpoints := append([]Endpoint{}, catalog...)
+	handWritten := map[string]bool{}
+	for _, e := range catalog {
+		handWritten[e.Path] = true
+	}
+	for _, e := range generatedCatalog {
+		if !handWritten[e.Path] {
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Path < endpoints[j].Path })
+	return endpoints
+}
+
+// SortedMethods returns the endpoint's methods in a stable order
+func (e Endpoint) SortedMethods() []string {
+	order := map[string]int{"GET": 0, "POST": 1, "PUT": 2, "PATCH": 3, "DELETE": 4}
+	methods := []string{}
+	for m := range e.Methods {
+		methods = append(methods, m)
+	}
+	sort.Slice(methods, func(i, j int) bool { return order[methods[i]] < order[methods[j]] })
+	return methods
+}
+
+// ValidOptions returns the paths in the catalog that support a method, sorted
+func ValidOptions(method string) []string {
+	names := []string{}
+	for _, e := range Endpoints() {
+		if _, ok := e.Methods[strings.ToUpper(method)]; ok {
+			names = append(names, e.Path)
+		}
+	}
+	return names
+}
+
+// ParameterNames returns the names of the query parameters of an endpoint method, or nil if it isn't in the catalog
+func ParameterNames(method string, path string) []string {
+	e, ok := GetEndpoint(path)
+	if !ok {
+		return nil
+	}
+	names := []string{}
+	for _, p := range e.Methods[strings.ToUpper(method)].Parameters {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// DescribeEndpoint writes an endpoint's methods, parameters, body, scopes and pagination support as text, or as JSON when output is OutputJSON
+func DescribeEndpoint(w io.Writer, path string, output string) error {
+	e, ok := GetEndpoint(path)
+	if !ok {
+		paths := []string{}
+		for _, e := range Endpoints() {
+			paths = append(paths, e.Path)
+		}
+		return fmt.Errorf("%v isn't in the endpoint catalog%v", path, didYouMean(path, paths))
+	}
+
+	if output == OutputJSON {
+		b, err := json.MarshalIndent(e, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Error marshalling json: %v", err)
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	fmt.Fprintln(w, e.Path)
+	if e.Deprecated {
+		fmt.Fprintln(w, "Deprecated")
+	}
+	for _, method := range e.SortedMethods() {
+		m := e.Methods[method]
+		fmt.Fprintf(w, "\n%v  %v\n", method, m.Description)
+
+		tokenTypes := strings.Join(m.TokenTypes, ", ")
+		if tokenTypes == "" {
+			tokenTypes = strings.Join([]string{scopes.AppAccessToken, scopes.UserAccessToken}, ", ")
+		}
+		requiredScopes := strings.Join(m.Scopes, " or ")
+		if requiredScopes == "" {
+			requiredScopes = "none"
+		}
+		fmt.Fprintf(w, "  Token types: %v\n  Scopes: %v\n  Paginated: %v\n", tokenTypes, requiredScopes, yesNo(m.Paginated))
+		if len(m.RequireOneOf) > 0 {
+			fmt.Fprintf(w, "  Requires one of: %v\n", strings.Join(m.RequireOneOf, ", "))
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if len(m.Parameters) > 0 {
+			fmt.Fprintln(w, "\n  Query parameters:")
+			fmt.Fprintln(tw, "    NAME\tTYPE\tREQUIRED\tMAX\tDESCRIPTION")
+			for _, p := range m.Parameters {
+				max := "1"
+				if p.Repeatable {
+					max = fmt.Sprint(p.MaxCount)
+				}
+				fmt.Fprintf(tw, "    %v\t%v\t%v\t%v\t%v\n", p.Name, p.Type, yesNo(p.Required), max, p.Description)
+			}
+			tw.Flush()
+		}
+		if len(m.Body) > 0 {
+			fmt.Fprintln(w, "\n  Body:")
+			fmt.Fprintln(tw, "    NAME\tTYPE\tREQUIRED\tDESCRIPTION")
+			for _, f := range m.Body {
+				fmt.Fprintf(tw, "    %v\t%v\t%v\t%v\n", f.Name, f.Type, yesNo(f.Required), f.Description)
+			}
+			tw.Flush()
+		}
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,193 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestCatalog(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	seen := map[string]bool{}
+	for _, e := range catalog {
+		a.False(seen[e.Path], e.Path)
+		seen[e.Path] = true
+		a.NotEmpty(e.Methods, e.Path)
+
+		for method, m := range e.Methods {
+			a.NotEmpty(m.Description, method+" "+e.Path)
+			for _, s := range m.Scopes {
+				_, ok := scopes.Get(s)
+				a.True(ok, "%v %v: unknown scope %v", method, e.Path, s)
+			}
+			for _, name := range m.RequireOneOf {
+				a.Contains(ParameterNames(method, e.Path), name)
+			}
+			if m.Paginated {
+				a.Contains(ParameterNames(method, e.Path), "after", method+" "+e.Path)
+			}
+		}
+	}
+}
+
+// endpoints Twitch has removed stay in the catalog, so completion still offers them
+func TestCatalogDeprecatedEndpoints(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	for _, path := range []string{"/entitlements/upload", "/moderation/banned/events", "/streams/tags", "/tags/streams"} {
+		e, ok := GetEndpoint(path)
+		a.True(ok, path)
+		a.True(e.Deprecated, path)
+	}
+}
+
+// the catalog should require the same scopes and token types as the mock API
+func TestCatalogMatchesMockAPI(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	sorted := func(s []string) []string {
+		s = append([]string{}, s...)
+		sort.Strings(s)
+		return s
+	}
+
+	compared := 0
+	for _, mock := range endpoints.All() {
+		e, ok := GetEndpoint(mock.Path())
+		if !ok {
+			continue
+		}
+		for method, m := range e.Methods {
+			if !mock.ValidMethod(method) {
+				continue
+			}
+			compared++
+
+			a.Equal(sorted(mock.GetRequiredScopes(method)), sorted(m.Scopes), method+" "+e.Path)
+
+			tokenTypes := []string{}
+			if tt, ok := mock.(mock_api.TokenTypeEndpoint); ok {
+				tokenTypes = tt.GetAcceptedTokenTypes(method)
+			}
+			a.ElementsMatch(tokenTypes, m.TokenTypes, method+" "+e.Path)
+		}
+	}
+	a.Greater(compared, 20)
+}
+
+func TestValidOptions(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	get := ValidOptions("GET")
+	a.NotEmpty(get)
+	a.Contains(get, "/users")
+	a.NotContains(get, "/channels/commercial")
+	a.True(sort.StringsAreSorted(get))
+
+	a.Contains(ValidOptions("post"), "/channels/commercial")
+
+	potato := ValidOptions("potato")
+	a.Empty(potato)
+}
+
+func TestValidateRequest(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	// paths that aren't in the catalog aren't validated
+	a.Nil(ValidateRequest("GET", "/potato", url.Values{"a": {"1"}}, []byte("{")))
+
+	a.Nil(ValidateRequest("GET", "/users", url.Values{"login": {"a", "b"}}, nil))
+	a.Nil(ValidateRequest("get", "/users", url.Values{}, nil))
+
+	err := ValidateRequest("POST", "/users", nil, nil)
+	a.ErrorContains(err, "Supported methods are: GET, PUT")
+
+	err = ValidateRequest("GET", "/users", url.Values{"logn": {"a"}}, nil)
+	a.ErrorContains(err, "unknown query parameter logn (did you mean login?)")
+
+	err = ValidateRequest("GET", "/users", url.Values{"potato": {"a"}}, nil)
+	a.ErrorContains(err, "unknown query parameter potato")
+	a.NotContains(err.Error(), "did you mean")
+
+	err = ValidateRequest("GET", "/channels/followers", url.Values{}, nil)
+	a.ErrorContains(err, "missing required query parameter broadcaster_id")
+
+	err = ValidateRequest("GET", "/channels/followers", url.Values{"broadcaster_id": {"1", "2"}}, nil)
+	a.ErrorContains(err, "query parameter broadcaster_id can only be given once")
+
+	ids := make([]string, 101)
+	err = ValidateRequest("GET", "/users", url.Values{"id": ids}, nil)
+	a.ErrorContains(err, "query parameter id can be given at most 100 times, got 101")
+
+	err = ValidateRequest("GET", "/clips", url.Values{"first": {"5"}}, nil)
+	a.ErrorContains(err, "one of the query parameters broadcaster_id, game_id, id is required")
+	a.Nil(ValidateRequest("GET", "/clips", url.Values{"game_id": {"1"}}, nil))
+
+	// every problem is reported at once
+	err = ValidateRequest("GET", "/channels/followers", url.Values{"usr_id": {"1"}}, nil)
+	a.ErrorContains(err, "usr_id (did you mean user_id?)")
+	a.ErrorContains(err, "missing required query parameter broadcaster_id")
+
+	// bodies
+	a.Nil(ValidateRequest("POST", "/channels/commercial", nil, []byte(`{"broadcaster_id":"1","length":30}`)))
+
+	err = ValidateRequest("POST", "/channels/commercial", nil, nil)
+	a.ErrorContains(err, "missing required body field broadcaster_id")
+
+	err = ValidateRequest("POST", "/channels/commercial", nil, []byte(`{"broadcaster_id":"1","length":"30"}`))
+	a.ErrorContains(err, "body field length must be of type integer")
+
+	err = ValidateRequest("POST", "/channels/commercial", nil, []byte(`{"broadcaster_id":"1","length":30.5}`))
+	a.ErrorContains(err, "body field length must be of type integer")
+
+	err = ValidateRequest("POST", "/channels/commercial", nil, []byte(`{"broadcaster_id":"1","length":30,"lenght":30}`))
+	a.ErrorContains(err, "unknown body field lenght (did you mean length?)")
+
+	err = ValidateRequest("POST", "/channels/commercial", nil, []byte(`[1]`))
+	a.ErrorContains(err, "body must be a JSON object")
+
+	err = ValidateRequest("GET", "/users", nil, []byte(`{"a":1}`))
+	a.ErrorContains(err, "doesn't accept a body")
+}
+
+func TestNewRequestValidation(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	// validation happens before client information is needed, so nothing is sent
+	err := NewRequest(RequestParameters{Method: "GET", Path: "/users", QueryParameters: []string{"logn=twitchdev"}})
+	a.ErrorContains(err, "did you mean login?")
+}
+
+func TestDescribeEndpoint(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var b bytes.Buffer
+	a.Nil(DescribeEndpoint(&b, "/streams/markers", ""))
+	a.Contains(b.String(), "GET  Gets the stream markers")
+	a.Contains(b.String(), "Scopes: user:read:broadcast or channel:manage:broadcast")
+	a.Contains(b.String(), "Token types: user_access")
+	a.Contains(b.String(), "Paginated: yes")
+	a.Contains(b.String(), "Requires one of: user_id, video_id")
+	a.Contains(b.String(), "POST  Adds a marker")
+	a.Regexp(`description\s+string\s+no\s+Description of the marker`, b.String())
+
+	b.Reset()
+	a.Nil(DescribeEndpoint(&b, "/users", OutputJSON))
+	var e Endpoint
+	a.Nil(json.Unmarshal(b.Bytes(), &e))
+	a.Equal("/users", e.Path)
+	a.Equal(100, e.Methods["GET"].Parameters[0].MaxCount)
+
+	err := DescribeEndpoint(&b, "/user", "")
+	a.ErrorContains(err, "did you mean /users?")
+}
@@ -4,29 +4,81 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
 )
 
+// sleep is replaced in tests
+var sleep = time.Sleep
+
 type RLClient struct {
 	client      *http.Client
-	RateLimiter *rate.Limiter
+	RateLimiter *rate.Limiter // optional; requests are also limited by the Ratelimit-* headers of previous responses
+
+	mu        sync.Mutex
+	remaining int // -1 until a response includes Ratelimit-Remaining
+	reset     time.Time
 }
 
 func (c *RLClient) Do(req *http.Request) (*http.Response, error) {
-	err := c.RateLimiter.Wait(context.Background())
-	if err != nil {
-		return nil, err
+	if c.RateLimiter != nil {
+		err := c.RateLimiter.Wait(context.Background())
+		if err != nil {
+			return nil, err
+		}
 	}
+	c.waitForReset()
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	c.updateRateLimit(resp)
 	return resp, nil
 }
 
+// waitForReset waits until the rate limit bucket refills if the last response said it was empty
+func (c *RLClient) waitForReset() {
+	c.mu.Lock()
+	wait := time.Duration(0)
+	if c.remaining == 0 {
+		wait = time.Until(c.reset)
+	}
+	c.mu.Unlock()
+
+	if wait > 0 {
+		fmt.Fprintf(os.Stderr, "Rate limit reached; waiting %v until it resets\n", wait.Round(time.Second))
+		sleep(wait)
+	}
+}
+
+func (c *RLClient) updateRateLimit(resp *http.Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	remaining, err := strconv.Atoi(resp.Header.Get("Ratelimit-Remaining"))
+	if err != nil {
+		remaining = -1
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("Ratelimit-Reset"), 10, 64)
+	if err == nil {
+		c.reset = time.Unix(reset, 0)
+	}
+
+	// a 429 without rate limit headers still means waiting before the next request
+	if resp.StatusCode == http.StatusTooManyRequests && (remaining < 0 || err != nil) {
+		remaining = 0
+		c.reset = time.Now().Add(time.Second)
+	}
+	c.remaining = remaining
+}
+
 func NewClient(l *rate.Limiter) *RLClient {
 	client := http.Client{
 		Timeout: time.Second * 10,
@@ -35,5 +87,6 @@ func NewClient(l *rate.Limiter) *RLClient {
 	return &RLClient{
 		client:      &client,
 		RateLimiter: l,
+		remaining:   -1,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -42,3 +43,39 @@ func TestNewClient(t *testing.T) {
 	_, err = c.Do(req)
 	a.NotNil(err)
 }
+
+func TestRateLimitHeaders(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	var waited []time.Duration
+	sleep = func(d time.Duration) { waited = append(waited, d) }
+	defer func() { sleep = time.Sleep }()
+
+	reset := time.Now().Add(30 * time.Second)
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Ratelimit-Remaining", "0")
+			w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		case 2:
+			w.Header().Set("Ratelimit-Remaining", "799")
+		case 3:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(nil)
+	for i := 0; i < 4; i++ {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+		_, err := c.Do(req)
+		a.Nil(err)
+	}
+
+	// waits for the reset after the first response, and briefly after the 429 without headers
+	a.Len(waited, 2)
+	a.InDelta(30*time.Second, waited[0], float64(2*time.Second))
+	a.LessOrEqual(waited[1], time.Second)
+}
@@ -0,0 +1,349 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// kinds of Difference
+const (
+	DiffMissing = "missing" // only in the first profile's response
+	DiffExtra   = "extra"   // only in the second profile's response
+	DiffType    = "type"    // the type differs
+	DiffValue   = "value"   // the value differs; only reported when comparing values
+	DiffStatus  = "status"  // the status code differs, so the bodies aren't compared
+)
+
+// DiffParameters describe requests sent with two profiles by Diff, such as production and the mock API
+type DiffParameters struct {
+	Requests []BatchRequest // as read by ReadBatchRequests
+	Profiles [2]string      // the profiles to send each request with; the config of the first is loaded when Diff is called
+	// UseProfile loads the config of a profile, including its secrets, so requests are sent with its credentials and BASE_URL
+	UseProfile     func(profile string) error
+	Values         bool      // report differing values as well as differing structure
+	SkipValidation bool      // send requests without checking them against the endpoint catalog
+	Output         io.Writer // a report for each request
+	Format         string    // OutputJSON for NDJSON, or text otherwise
+}
+
+// Difference is a difference between the responses to the same request
+type Difference struct {
+	Kind  string `json:"kind"`
+	Path  string `json:"path"`            // jq-style, e.g. .data[].user_id
+	Left  string `json:"left,omitempty"`  // the type or value in the first profile's response
+	Right string `json:"right,omitempty"` // the type or value in the second profile's response
+}
+
+// DiffResult is the report for one request
+type DiffResult struct {
+	Line        int          `json:"line,omitempty"`
+	Method      string       `json:"method"`
+	Path        string       `json:"path"`
+	Query       url.Values   `json:"query,omitempty"`
+	Status      [2]int       `json:"status"`
+	Differences []Difference `json:"differences"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// Diff sends every request with both profiles and reports how the responses differ. By default only their structure is compared:
+// fields missing from either and fields of different types. An error is returned if any responses differ.
+func Diff(p DiffParameters) error {
+	if !p.SkipValidation {
+		for _, req := range p.Requests {
+			if err := ValidateRequest(req.Method, req.Path, url.Values(req.Query), req.Body); err != nil {
+				return err
+			}
+		}
+	}
+
+	responses := [2][]apiRequestResponse{}
+	errs := [2][]error{}
+	for i, profile := range p.Profiles {
+		if i > 0 {
+			if err := p.UseProfile(profile); err != nil {
+				return fmt.Errorf("Error loading profile %v: %v", profile, err)
+			}
+		}
+		var err error
+		responses[i], errs[i], err = sendDiffRequests(p.Requests)
+		if err != nil {
+			return fmt.Errorf("Error fetching client information for profile %v: %v", profile, err)
+		}
+	}
+
+	differing := 0
+	for j, req := range p.Requests {
+		result := DiffResult{Line: req.line, Method: req.Method, Path: req.Path, Query: url.Values(req.Query), Differences: []Difference{}}
+		left, right := responses[0][j], responses[1][j]
+		result.Status = [2]int{left.StatusCode, right.StatusCode}
+
+		switch {
+		case errs[0][j] != nil:
+			result.Error = fmt.Sprintf("%v: %v", p.Profiles[0], errs[0][j])
+		case errs[1][j] != nil:
+			result.Error = fmt.Sprintf("%v: %v", p.Profiles[1], errs[1][j])
+		case left.StatusCode != right.StatusCode:
+			result.Differences = append(result.Differences, Difference{Kind: DiffStatus, Path: ".", Left: fmt.Sprint(left.StatusCode), Right: fmt.Sprint(right.StatusCode)})
+		default:
+			result.Differences = CompareResponses(left.Body, right.Body, p.Values)
+		}
+
+		if result.Error != "" || len(result.Differences) > 0 {
+			differing++
+		}
+		if err := writeDiffResult(p.Output, p.Format, p.Profiles, result); err != nil {
+			return err
+		}
+	}
+
+	if p.Format != OutputJSON {
+		fmt.Fprintf(p.Output, "%v of %v responses differ between %v and %v\n", differing, len(p.Requests), p.Profiles[0], p.Profiles[1])
+	}
+	if differing > 0 {
+		return fmt.Errorf("%v of %v responses differ", differing, len(p.Requests))
+	}
+	return nil
+}
+
+// sendDiffRequests sends every request with the credentials and BASE_URL of the active profile, returning the response or error of each
+func sendDiffRequests(requests []BatchRequest) ([]apiRequestResponse, []error, error) {
+	client, err := GetClientInformation()
+	if err != nil {
+		return nil, nil, err
+	}
+	// the base URL is set for every profile, so production is used after a profile with BASE_URL set
+	baseURL = productionBaseURL
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+
+	responses := make([]apiRequestResponse, len(requests))
+	errs := make([]error, len(requests))
+	for i, req := range requests {
+		u, err := url.Parse(baseURL + req.Path)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		u.RawQuery = url.Values(req.Query).Encode()
+		responses[i], errs[i] = sendWithRefresh(&client, req.Method, u.String(), req.Body)
+	}
+	return responses, errs, nil
+}
+
+func writeDiffResult(w io.Writer, format string, profiles [2]string, r DiffResult) error {
+	if format == OutputJSON {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	request := r.Method + " " + r.Path
+	if len(r.Query) > 0 {
+		request += "?" + r.Query.Encode()
+	}
+	if r.Error != "" {
+		fmt.Fprintf(w, "%v\n  error: %v\n", request, r.Error)
+		return nil
+	}
+	if len(r.Differences) == 0 {
+		fmt.Fprintf(w, "%v\n  no differences\n", request)
+		return nil
+	}
+
+	fmt.Fprintln(w, request)
+	for _, d := range r.Differences {
+		switch d.Kind {
+		case DiffMissing:
+			fmt.Fprintf(w, "  missing from %v: %v (%v)\n", profiles[1], d.Path, d.Left)
+		case DiffExtra:
+			fmt.Fprintf(w, "  extra in %v: %v (%v)\n", profiles[1], d.Path, d.Right)
+		default:
+			fmt.Fprintf(w, "  %v differs at %v: %v in %v, %v in %v\n", d.Kind, d.Path, d.Left, profiles[0], d.Right, profiles[1])
+		}
+	}
+	return nil
+}
+
+// CompareResponses returns the differences between two JSON response bodies, in the order of their paths.
+// Arrays are compared by the combined structure of their items and null matches any type, unless values are compared too.
+func CompareResponses(left []byte, right []byte, values bool) []Difference {
+	l, lErr := decodeJSON(left)
+	r, rErr := decodeJSON(right)
+	if lErr != nil || rErr != nil {
+		// bodies that aren't JSON, such as empty 204 responses, are compared as text
+		if !bytes.Equal(bytes.TrimSpace(left), bytes.TrimSpace(right)) && (values || lErr == nil || rErr == nil) {
+			return []Difference{{Kind: DiffType, Path: ".", Left: bodyType(left, lErr), Right: bodyType(right, rErr)}}
+		}
+		return []Difference{}
+	}
+
+	diffs := []Difference{}
+	if values {
+		compareValues(".", l, r, &diffs)
+	} else {
+		compareShapes(".", shapeOf(l), shapeOf(r), &diffs)
+	}
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs
+}
+
+func decodeJSON(b []byte) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func bodyType(b []byte, err error) string {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return "empty"
+	}
+	if err != nil {
+		return "text"
+	}
+	return "json"
+}
+
+// shape is the structure of a JSON value: its type, and the shapes of its fields or items
+type shape struct {
+	Type   string
+	Fields map[string]*shape // of objects
+	Items  *shape            // of arrays; nil for empty arrays
+}
+
+func shapeOf(v interface{}) *shape {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		s := &shape{Type: TypeObject, Fields: map[string]*shape{}}
+		for k, value := range t {
+			s.Fields[k] = shapeOf(value)
+		}
+		return s
+	case []interface{}:
+		s := &shape{Type: TypeArray}
+		for _, item := range t {
+			s.Items = mergeShapes(s.Items, shapeOf(item))
+		}
+		return s
+	case string:
+		return &shape{Type: TypeString}
+	case json.Number:
+		return &shape{Type: TypeNumber}
+	case bool:
+		return &shape{Type: TypeBoolean}
+	}
+	return &shape{Type: "null"}
+}
+
+// mergeShapes combines the shapes of array items, so each field appears if any item has it. Null takes the type of other items.
+func mergeShapes(a *shape, b *shape) *shape {
+	if a == nil || a.Type == "null" {
+		return b
+	}
+	if b == nil || b.Type == "null" || a.Type != b.Type {
+		return a
+	}
+	switch a.Type {
+	case TypeObject:
+		merged := &shape{Type: TypeObject, Fields: map[string]*shape{}}
+		for k, s := range a.Fields {
+			merged.Fields[k] = s
+		}
+		for k, s := range b.Fields {
+			merged.Fields[k] = mergeShapes(merged.Fields[k], s)
+		}
+		return merged
+	case TypeArray:
+		return &shape{Type: TypeArray, Items: mergeShapes(a.Items, b.Items)}
+	}
+	return a
+}
+
+func compareShapes(path string, l *shape, r *shape, diffs *[]Difference) {
+	// empty arrays say nothing about the structure of their items, and whether a field is null depends on the data
+	if l == nil || r == nil || l.Type == "null" || r.Type == "null" {
+		return
+	}
+	if l.Type != r.Type {
+		*diffs = append(*diffs, Difference{Kind: DiffType, Path: path, Left: l.Type, Right: r.Type})
+		return
+	}
+	switch l.Type {
+	case TypeObject:
+		for k, s := range l.Fields {
+			if _, ok := r.Fields[k]; !ok {
+				*diffs = append(*diffs, Difference{Kind: DiffMissing, Path: childPath(path, k), Left: s.Type})
+			}
+		}
+		for k, s := range r.Fields {
+			if _, ok := l.Fields[k]; !ok {
+				*diffs = append(*diffs, Difference{Kind: DiffExtra, Path: childPath(path, k), Right: s.Type})
+			} else {
+				compareShapes(childPath(path, k), l.Fields[k], s, diffs)
+			}
+		}
+	case TypeArray:
+		compareShapes(strings.TrimSuffix(path, ".")+"[]", l.Items, r.Items, diffs)
+	}
+}
+
+func compareValues(path string, l interface{}, r interface{}, diffs *[]Difference) {
+	lShape, rShape := shapeOf(l), shapeOf(r)
+	if lShape.Type != rShape.Type {
+		*diffs = append(*diffs, Difference{Kind: DiffType, Path: path, Left: lShape.Type, Right: rShape.Type})
+		return
+	}
+	switch lt := l.(type) {
+	case map[string]interface{}:
+		rt := r.(map[string]interface{})
+		for k, v := range lt {
+			if _, ok := rt[k]; !ok {
+				*diffs = append(*diffs, Difference{Kind: DiffMissing, Path: childPath(path, k), Left: shapeOf(v).Type})
+			}
+		}
+		for k, v := range rt {
+			if _, ok := lt[k]; !ok {
+				*diffs = append(*diffs, Difference{Kind: DiffExtra, Path: childPath(path, k), Right: shapeOf(v).Type})
+			} else {
+				compareValues(childPath(path, k), lt[k], v, diffs)
+			}
+		}
+	case []interface{}:
+		rt := r.([]interface{})
+		prefix := strings.TrimSuffix(path, ".")
+		for i := 0; i < len(lt) || i < len(rt); i++ {
+			itemPath := fmt.Sprintf("%v[%v]", prefix, i)
+			if i >= len(rt) {
+				*diffs = append(*diffs, Difference{Kind: DiffMissing, Path: itemPath, Left: shapeOf(lt[i]).Type})
+			} else if i >= len(lt) {
+				*diffs = append(*diffs, Difference{Kind: DiffExtra, Path: itemPath, Right: shapeOf(rt[i]).Type})
+			} else {
+				compareValues(itemPath, lt[i], rt[i], diffs)
+			}
+		}
+	default:
+		if fmt.Sprint(l) != fmt.Sprint(r) {
+			*diffs = append(*diffs, Difference{Kind: DiffValue, Path: path, Left: fmt.Sprint(l), Right: fmt.Sprint(r)})
+		}
+	}
+}
+
+func childPath(path string, key string) string {
+	return strings.TrimSuffix(path, ".") + "." + key
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestCompareResponses(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	prod := []byte(`{"data":[{"id":"1","login":"a","view_count":5,"tags":["x"],"offline_image_url":null},{"id":"2","login":"b","created_at":"2016-12-14T20:32:28Z"}],"pagination":{}}`)
+	mock := []byte(`{"data":[{"id":"3","login":"c","view_count":"5","tags":[],"offline_image_url":"https://example.com","type":""}],"pagination":{}}`)
+
+	a.Equal([]Difference{
+		{Kind: DiffMissing, Path: ".data[].created_at", Left: TypeString},
+		{Kind: DiffExtra, Path: ".data[].type", Right: TypeString},
+		{Kind: DiffType, Path: ".data[].view_count", Left: TypeNumber, Right: TypeString},
+	}, CompareResponses(prod, mock, false))
+
+	// the same structure with different values
+	a.Empty(CompareResponses([]byte(`{"data":[{"id":"1"}]}`), []byte(`{"data":[{"id":"2"},{"id":"3"}]}`), false))
+	a.Equal([]Difference{
+		{Kind: DiffValue, Path: ".data[0].id", Left: "1", Right: "2"},
+		{Kind: DiffExtra, Path: ".data[1]", Right: TypeObject},
+	}, CompareResponses([]byte(`{"data":[{"id":"1"}]}`), []byte(`{"data":[{"id":"2"},{"id":"3"}]}`), true))
+
+	a.Equal([]Difference{{Kind: DiffType, Path: ".", Left: "empty", Right: "json"}}, CompareResponses(nil, []byte(`{}`), false))
+	a.Empty(CompareResponses(nil, []byte(" "), false))
+}
+
+func TestDiff(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	prod := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("Bearer default-token", r.Header.Get("Authorization"))
+		if r.URL.Path == "/videos" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","status":404,"message":""}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"1","login":"twitchdev","broadcaster_type":"partner"}]}`))
+	}))
+	defer prod.Close()
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.Equal("Bearer mock-token", r.Header.Get("Authorization"))
+		if r.URL.Path == "/videos" {
+			w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"2","login":"twitchdev"}]}`))
+	}))
+	defer mock.Close()
+
+	defaultURL := baseURL
+	defer func() { baseURL = defaultURL }()
+	defer viper.Set("BASE_URL", "")
+
+	profiles := map[string]string{"default": prod.URL, "mock": mock.URL}
+	useProfile := func(profile string) error {
+		viper.Set("BASE_URL", profiles[profile])
+		viper.Set("clientid", "1111")
+		viper.Set("accesstoken", profile+"-token")
+		viper.Set("tokenexpiration", "0")
+		return nil
+	}
+	useProfile("default")
+
+	requests, err := ReadBatchRequests(strings.NewReader(`{"path":"/users","query":{"login":"twitchdev"}}
+{"path":"/videos","query":{"id":"1"}}`))
+	a.Nil(err)
+
+	var out strings.Builder
+	err = Diff(DiffParameters{
+		Requests:   requests,
+		Profiles:   [2]string{"default", "mock"},
+		UseProfile: useProfile,
+		Output:     &out,
+		Format:     OutputJSON,
+	})
+	a.EqualError(err, "2 of 2 responses differ")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	a.Len(lines, 2)
+	var result DiffResult
+	a.Nil(json.Unmarshal([]byte(lines[0]), &result))
+	a.Equal(1, result.Line)
+	a.Equal([2]int{200, 200}, result.Status)
+	a.Equal([]Difference{{Kind: DiffMissing, Path: ".data[].broadcaster_type", Left: TypeString}}, result.Differences)
+	a.Nil(json.Unmarshal([]byte(lines[1]), &result))
+	a.Equal([]Difference{{Kind: DiffStatus, Path: ".", Left: "404", Right: "200"}}, result.Differences)
+
+	// text reports describe each difference in terms of the profiles
+	useProfile("default")
+	out.Reset()
+	err = Diff(DiffParameters{Requests: requests[:1], Profiles: [2]string{"default", "mock"}, UseProfile: useProfile, Output: &out})
+	a.NotNil(err)
+	a.Equal(`GET /users?login=twitchdev
+  missing from mock: .data[].broadcaster_type (string)
+1 of 1 responses differ between default and mock
+`, out.String())
+
+	// requests are validated before anything is sent
+	requests, err = ReadBatchRequests(strings.NewReader(`{"path":"/users","query":{"potato":"1"}}`))
+	a.Nil(err)
+	err = Diff(DiffParameters{Requests: requests, Profiles: [2]string{"default", "mock"}, UseProfile: useProfile, Output: &out})
+	a.ErrorContains(err, "Invalid request to GET /users")
+}
@@ -0,0 +1,530 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/viper"
+)
+
+// explore actions, offered after each response
+const (
+	actionNextPage  = "Next page"
+	actionSendAgain = "Send again"
+	actionEdit      = "Edit request"
+	actionSave      = "Save as snippet"
+	actionNew       = "New request"
+	actionSnippet   = "Open snippet"
+	actionQuit      = "Quit"
+)
+
+type explorer struct {
+	client clientInformation
+}
+
+// explorePage is one response shown by Explore
+type explorePage struct {
+	StatusCode int
+	Body       []byte
+	Cursor     string // the cursor of the next page, if there is one
+}
+
+// Explore is an interactive explorer for the endpoint catalog: an endpoint is picked, its query parameters and body are filled in as a form,
+// and the response is shown a page at a time. Requests can be saved as snippets to open again later.
+func Explore() error {
+	client, err := GetClientInformation()
+	if err != nil {
+		return fmt.Errorf("Error fetching client information: %v", err.Error())
+	}
+	if viper.GetString("BASE_URL") != "" {
+		baseURL = viper.GetString("BASE_URL")
+	}
+	fmt.Printf("Exploring %v. Press Ctrl+C to quit.\n", baseURL)
+
+	e := &explorer{client: client}
+	action := ""
+	for {
+		snippets, err := SavedRequests()
+		if err != nil {
+			return err
+		}
+		if action == "" && len(snippets) == 0 {
+			action = actionNew
+		} else if action == "" {
+			action, err = e.choose("What next?", []string{actionNew, actionSnippet, actionQuit})
+			if err != nil {
+				return quitError(err)
+			}
+		}
+
+		var r SavedRequest
+		switch action {
+		case actionNew:
+			r, err = e.form(SavedRequest{})
+		case actionSnippet:
+			r, err = e.chooseSnippet(snippets)
+			if err == nil {
+				r, err = e.render(r)
+			}
+		case actionQuit:
+			return nil
+		}
+		if err != nil && quitError(err) == nil {
+			return nil
+		} else if err != nil {
+			// e.g. a snippet that needs a user access token; pick another request
+			fmt.Println(err.Error())
+			action = ""
+			continue
+		}
+
+		action, err = e.session(r)
+		if err != nil {
+			return quitError(err)
+		}
+	}
+}
+
+// quitError returns nil when the user quit with Ctrl+C or Ctrl+D
+func quitError(err error) error {
+	if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+		return nil
+	}
+	return err
+}
+
+// session sends a request and shows its responses until another request is wanted, returning the action that ended it
+func (e *explorer) session(r SavedRequest) (string, error) {
+	cursor, next := "", ""
+	pageNumber := 1
+	sent := false
+	send := true
+	for {
+		if send {
+			sent, next = false, ""
+			p := r.parameters()
+			if err := ValidateRequest(p.Method, p.Path, parseQueryParameters(p.QueryParameters), p.Body); err != nil {
+				fmt.Println(err.Error())
+			} else if page, err := e.fetchPage(p, cursor); err != nil {
+				fmt.Printf("Error sending request: %v\n", err)
+			} else {
+				fmt.Printf("\n%v %v\n", p.Method, p.Path+queryString(p.QueryParameters))
+				fmt.Printf("%v %v, page %v\n", page.StatusCode, http.StatusText(page.StatusCode), pageNumber)
+				fmt.Println(prettyBody(page.Body))
+				sent, next = true, page.Cursor
+			}
+		}
+		send = true
+
+		actions := []string{}
+		if next != "" && next != cursor {
+			actions = append(actions, actionNextPage)
+		}
+		if sent {
+			actions = append(actions, actionSendAgain)
+		}
+		actions = append(actions, actionEdit, actionSave, actionNew, actionQuit)
+
+		action, err := e.choose("What next?", actions)
+		if err != nil {
+			return "", err
+		}
+		switch action {
+		case actionNextPage:
+			cursor = next
+			pageNumber++
+		case actionEdit:
+			r, err = e.form(r)
+			if err != nil {
+				return "", err
+			}
+			cursor, pageNumber = "", 1
+		case actionSave:
+			if err := e.save(r); err != nil {
+				return "", err
+			}
+			send = false
+		case actionNew, actionQuit:
+			return action, nil
+		}
+	}
+}
+
+// fetchPage sends a request for the page after cursor
+func (e *explorer) fetchPage(p RequestParameters, cursor string) (explorePage, error) {
+	u, err := requestURL(p, cursor)
+	if err != nil {
+		return explorePage{}, err
+	}
+	resp, err := sendWithRefresh(&e.client, strings.ToUpper(p.Method), u.String(), p.Body)
+	if err != nil {
+		return explorePage{}, err
+	}
+	return explorePage{StatusCode: resp.StatusCode, Body: resp.Body, Cursor: responseCursor(resp.Body)}, nil
+}
+
+// sendWithRefresh sends a request, refreshing the token in client once and sending it again if the token is rejected
+func sendWithRefresh(client *clientInformation, method string, u string, body []byte) (apiRequestResponse, error) {
+	refreshed := false
+	for {
+		resp, err := apiRequest(method, u, body, apiRequestParameters{
+			ClientID: client.ClientID,
+			Token:    client.Token,
+		})
+		if err != nil {
+			return resp, err
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && !refreshed && isInvalidTokenResponse(resp.Body) {
+			refreshed = true
+			*client, err = refreshClientInformation(client.ClientID)
+			if err != nil {
+				return resp, fmt.Errorf("Error refreshing token: %v", err.Error())
+			}
+			continue
+		}
+		return resp, nil
+	}
+}
+
+// choose shows a menu of options and returns the one picked
+func (e *explorer) choose(label string, options []string) (string, error) {
+	prompt := promptui.Select{Label: label, Items: options, Size: len(options), HideSelected: true}
+	_, option, err := prompt.Run()
+	return option, err
+}
+
+// chooseSnippet picks a saved request to send
+func (e *explorer) chooseSnippet(snippets []SavedRequest) (SavedRequest, error) {
+	prompt := promptui.Select{
+		Label: "Snippet",
+		Items: snippets,
+		Size:  10,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "> {{ .Name | cyan }} {{ .Method }} {{ .Path }}",
+			Inactive: "  {{ .Name }} {{ .Method }} {{ .Path }}",
+			Selected: "{{ .Name }}",
+		},
+		Searcher: func(input string, i int) bool {
+			return strings.Contains(strings.ToLower(snippets[i].Name+" "+snippets[i].Path), strings.ToLower(input))
+		},
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return SavedRequest{}, err
+	}
+	return snippets[i], nil
+}
+
+// render asks for the values of a snippet's template variables, other than BuiltinVariables, and renders it
+func (e *explorer) render(r SavedRequest) (SavedRequest, error) {
+	names, err := r.Variables()
+	if err != nil {
+		return r, err
+	}
+	vars := map[string]string{}
+	for _, name := range names {
+		if isBuiltinVariable(name) {
+			continue
+		}
+		prompt := promptui.Prompt{Label: name}
+		if vars[name], err = prompt.Run(); err != nil {
+			return r, err
+		}
+	}
+	return r.Render(vars)
+}
+
+// form asks for the endpoint, method, query parameters and body of a request, starting from the values in r
+func (e *explorer) form(r SavedRequest) (SavedRequest, error) {
+	endpoints := Endpoints()
+	cursorPos := 0
+	for i, ep := range endpoints {
+		if ep.Path == r.Path {
+			cursorPos = i
+		}
+	}
+	prompt := promptui.Select{
+		Label:     "Endpoint",
+		Items:     endpoints,
+		Size:      15,
+		CursorPos: cursorPos,
+		Templates: &promptui.SelectTemplates{
+			Label:    "{{ . }}",
+			Active:   "> {{ .Path | cyan }}{{ if .Deprecated }} (deprecated){{ end }}",
+			Inactive: "  {{ .Path }}{{ if .Deprecated }} (deprecated){{ end }}",
+			Selected: "Endpoint: {{ .Path }}",
+			Details:  "{{ range $method, $m := .Methods }}{{ $method }}: {{ $m.Description }}\n{{ end }}",
+		},
+		Searcher: func(input string, i int) bool {
+			return strings.Contains(endpoints[i].Path, strings.ToLower(strings.TrimSpace(input)))
+		},
+		StartInSearchMode: r.Path == "",
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return r, err
+	}
+	endpoint := endpoints[i]
+
+	method := endpoint.SortedMethods()[0]
+	if len(endpoint.Methods) > 1 {
+		method, err = e.choose("Method", endpoint.SortedMethods())
+		if err != nil {
+			return r, err
+		}
+	}
+	m := endpoint.Methods[method]
+
+	// values are only carried over when editing the same request
+	values := map[string]string{}
+	if endpoint.Path == r.Path && method == strings.ToUpper(r.Method) {
+		values = formValues(r)
+	}
+
+	for _, param := range m.Parameters {
+		values[param.Name], err = e.field(param.Name, param.Type, param.Description, param.Required, param.Repeatable, values[param.Name])
+		if err != nil {
+			return r, err
+		}
+	}
+	for _, field := range m.Body {
+		values[bodyPrefix+field.Name], err = e.field(field.Name, field.Type, field.Description, field.Required, false, values[bodyPrefix+field.Name])
+		if err != nil {
+			return r, err
+		}
+	}
+
+	body, err := formBody(m.Body, values)
+	if err != nil {
+		return r, err
+	}
+	return SavedRequest{Name: r.Name, Method: method, Path: endpoint.Path, QueryParameters: formQuery(m.Parameters, values), Body: string(body)}, nil
+}
+
+// field asks for one query parameter or body field
+func (e *explorer) field(name string, typ string, description string, required bool, repeatable bool, value string) (string, error) {
+	hints := []string{typ}
+	if required {
+		hints = append(hints, "required")
+	}
+	if repeatable {
+		hints = append(hints, "comma separated")
+	}
+	if description != "" {
+		fmt.Println(promptui.Styler(promptui.FGFaint)(description))
+	}
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("%v (%v)", name, strings.Join(hints, ", ")),
+		Default:   value,
+		AllowEdit: true,
+		Validate: func(s string) error {
+			return checkFormValue(typ, required, repeatable, s)
+		},
+	}
+	return prompt.Run()
+}
+
+// save asks for a name and saves the request as a snippet
+func (e *explorer) save(r SavedRequest) error {
+	prompt := promptui.Prompt{
+		Label:     "Snippet name",
+		Default:   r.Name,
+		AllowEdit: true,
+		Validate: func(s string) error {
+			if !validSavedRequestName.MatchString(s) {
+				return errors.New("letters, numbers, dots, dashes and underscores only")
+			}
+			return nil
+		},
+	}
+	if r.Name == "" {
+		prompt.Default = strings.ToLower(r.Method) + strings.ReplaceAll(r.Path, "/", "-")
+	}
+	name, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+	r.Name = name
+	if err := SaveRequest(r); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %v. It can also be sent with:\n%v\n", r.Name, r.Command())
+	return nil
+}
+
+func (r SavedRequest) parameters() RequestParameters {
+	return RequestParameters{Method: strings.ToUpper(r.Method), Path: r.Path, QueryParameters: r.QueryParameters, Body: []byte(r.Body)}
+}
+
+// bodyPrefix keeps body fields apart from query parameters of the same name in form values
+const bodyPrefix = "body."
+
+// checkFormValue returns an error if s isn't a valid value of typ
+func checkFormValue(typ string, required bool, repeatable bool, s string) error {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		if required {
+			return errors.New("required")
+		}
+		return nil
+	}
+
+	values := []string{s}
+	if repeatable {
+		values = strings.Split(s, ",")
+	}
+	for _, v := range values {
+		if _, err := formValue(typ, strings.TrimSpace(v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formValue converts a value typed into the form to typ
+func formValue(typ string, s string) (interface{}, error) {
+	switch typ {
+	case TypeInteger:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return i, nil
+	case TypeNumber:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		return f, nil
+	case TypeBoolean:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.New("must be true or false")
+		}
+		return b, nil
+	case TypeObject, TypeArray:
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, fmt.Errorf("must be a JSON %v", typ)
+		}
+		return v, nil
+	}
+	return s, nil
+}
+
+// formQuery returns the query parameters filled in on a form, in `key=value` format
+func formQuery(params []Parameter, values map[string]string) []string {
+	query := []string{}
+	for _, param := range params {
+		v := strings.TrimSpace(values[param.Name])
+		if v == "" {
+			continue
+		}
+		if !param.Repeatable {
+			query = append(query, param.Name+"="+v)
+			continue
+		}
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				query = append(query, param.Name+"="+item)
+			}
+		}
+	}
+	return query
+}
+
+// formBody returns the JSON body filled in on a form, or nil if no fields were filled in
+func formBody(fields []BodyField, values map[string]string) ([]byte, error) {
+	body := map[string]interface{}{}
+	for _, field := range fields {
+		v := strings.TrimSpace(values[bodyPrefix+field.Name])
+		if v == "" {
+			continue
+		}
+		value, err := formValue(field.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%v %v", field.Name, err)
+		}
+		body[field.Name] = value
+	}
+	if len(body) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(body)
+}
+
+// formValues returns the values of a request as they're shown on a form
+func formValues(r SavedRequest) map[string]string {
+	values := map[string]string{}
+	q := parseQueryParameters(r.QueryParameters)
+	for name, v := range q {
+		values[name] = strings.Join(v, ",")
+	}
+
+	var body map[string]interface{}
+	if json.Unmarshal([]byte(r.Body), &body) == nil {
+		for name, v := range body {
+			if s, ok := v.(string); ok {
+				values[bodyPrefix+name] = s
+				continue
+			}
+			b, _ := json.Marshal(v)
+			values[bodyPrefix+name] = string(b)
+		}
+	}
+	return values
+}
+
+// responseCursor returns the cursor of the next page of a response; extensions/live returns it as a string rather than an object
+func responseCursor(body []byte) string {
+	var r struct {
+		Pagination json.RawMessage `json:"pagination"`
+	}
+	if json.Unmarshal(body, &r) != nil || len(r.Pagination) == 0 {
+		return ""
+	}
+	var pagination struct {
+		Cursor string `json:"cursor"`
+	}
+	if json.Unmarshal(r.Pagination, &pagination) == nil {
+		return pagination.Cursor
+	}
+	var cursor string
+	json.Unmarshal(r.Pagination, &cursor)
+	return cursor
+}
+
+// queryString returns query parameters as they appear in a URL, including the leading ?
+func queryString(query []string) string {
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + strings.Join(query, "&")
+}
+
+// prettyBody indents a JSON response body, coloured except on Windows
+func prettyBody(body []byte) string {
+	var obj interface{}
+	if len(body) == 0 || json.Unmarshal(body, &obj) != nil {
+		return string(body)
+	}
+	if runtime.GOOS == "windows" {
+		s, _ := json.MarshalIndent(obj, "", "  ")
+		return string(s)
+	}
+	s, err := jsonFormatter().Marshal(obj)
+	if err != nil {
+		return string(body)
+	}
+	return string(s)
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestFormQuery(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	params := []Parameter{
+		{Name: "broadcaster_id", Type: TypeString, Required: true},
+		{Name: "user_id", Type: TypeString, Repeatable: true},
+		{Name: "first", Type: TypeInteger},
+	}
+	query := formQuery(params, map[string]string{"broadcaster_id": "1", "user_id": "2, 3,", "first": ""})
+	a.Equal([]string{"broadcaster_id=1", "user_id=2", "user_id=3"}, query)
+
+	values := formValues(SavedRequest{QueryParameters: query})
+	a.Equal("1", values["broadcaster_id"])
+	a.Equal("2,3", values["user_id"])
+
+	a.Nil(checkFormValue(TypeInteger, false, false, ""))
+	a.EqualError(checkFormValue(TypeString, true, false, " "), "required")
+	a.EqualError(checkFormValue(TypeInteger, false, true, "1,two"), "must be an integer")
+	a.Nil(checkFormValue(TypeInteger, false, true, "1, 2"))
+}
+
+func TestFormBody(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	fields := []BodyField{
+		{Name: "title", Type: TypeString},
+		{Name: "delay", Type: TypeInteger},
+		{Name: "is_branded_content", Type: TypeBoolean},
+		{Name: "tags", Type: TypeArray},
+		{Name: "game_id", Type: TypeString},
+	}
+	body, err := formBody(fields, map[string]string{
+		"body.title":              "Live",
+		"body.delay":              "30",
+		"body.is_branded_content": "true",
+		"body.tags":               `["English"]`,
+	})
+	a.Nil(err)
+	a.JSONEq(`{"title":"Live","delay":30,"is_branded_content":true,"tags":["English"]}`, string(body))
+
+	values := formValues(SavedRequest{Body: string(body)})
+	a.Equal("Live", values["body.title"])
+	a.Equal("30", values["body.delay"])
+	a.Equal(`["English"]`, values["body.tags"])
+
+	body, err = formBody(fields, map[string]string{})
+	a.Nil(err)
+	a.Nil(body)
+
+	_, err = formBody(fields, map[string]string{"body.tags": "English"})
+	a.EqualError(err, "tags must be a JSON array")
+}
+
+func TestResponseCursor(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal("abc", responseCursor([]byte(`{"data":[],"pagination":{"cursor":"abc"}}`)))
+	a.Equal("abc", responseCursor([]byte(`{"data":[],"pagination":"abc"}`)))
+	a.Equal("", responseCursor([]byte(`{"data":[],"pagination":{}}`)))
+	a.Equal("", responseCursor([]byte(`{"data":[]}`)))
+	a.Equal("", responseCursor([]byte(`potato`)))
+}
+
+func TestExplorerFetchPage(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"data":[{"id":"1"}],"pagination":{"cursor":"page2"}}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"2"}],"pagination":{}}`))
+	}))
+	defer ts.Close()
+
+	defaultURL := baseURL
+	baseURL = ts.URL
+	defer func() { baseURL = defaultURL }()
+
+	e := &explorer{client: clientInformation{ClientID: "1111", Token: "4567"}}
+	p := SavedRequest{Method: "GET", Path: "/users", QueryParameters: []string{"login=twitchdev"}}.parameters()
+
+	page, err := e.fetchPage(p, "")
+	a.Nil(err)
+	a.Equal(http.StatusOK, page.StatusCode)
+	a.Equal("page2", page.Cursor)
+
+	page, err = e.fetchPage(p, page.Cursor)
+	a.Nil(err)
+	a.JSONEq(`{"data":[{"id":"2"}],"pagination":{}}`, string(page.Body))
+	a.Empty(page.Cursor)
+}
@@ -0,0 +1,255 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const ExportCurl = "curl"
+
+// ExportFormats are the values accepted for RequestParameters.Export
+var ExportFormats = []string{ExportCurl}
+
+// tokenPlaceholder replaces the token in exported requests unless it's included, so they can still be run with the token in an environment variable
+const tokenPlaceholder = "$TWITCH_TOKEN"
+
+// curlCommand returns a curl command that sends the same request as apiRequest
+func curlCommand(method string, u string, body []byte, client clientInformation, includeToken bool) string {
+	token := tokenPlaceholder
+	if includeToken {
+		token = client.Token
+	}
+
+	lines := []string{fmt.Sprintf("curl -X %v %v", method, shellQuote(u))}
+	lines = append(lines, "-H "+shellQuote("Client-ID: "+client.ClientID))
+	if includeToken {
+		lines = append(lines, "-H "+shellQuote("Authorization: Bearer "+token))
+	} else {
+		// double quoted, so the shell expands the placeholder
+		lines = append(lines, fmt.Sprintf("-H \"Authorization: Bearer %v\"", token))
+	}
+	if len(body) > 0 {
+		lines = append(lines, "-H "+shellQuote("Content-Type: application/json"))
+		lines = append(lines, "--data-raw "+shellQuote(string(body)))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// shellQuote quotes a string for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// harRecorder collects the requests made by apiRequest, to append to a HAR archive. It's safe for concurrent use.
+type harRecorder struct {
+	includeToken bool
+
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+func newHARRecorder(includeToken bool) *harRecorder {
+	return &harRecorder{includeToken: includeToken}
+}
+
+// record adds a request and its response; resp is nil if the request failed
+func (h *harRecorder) record(req *http.Request, payload []byte, resp *http.Response, body []byte, started time.Time, elapsed time.Duration) {
+	if h == nil {
+		return
+	}
+
+	e := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds(elapsed),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         req.URL.String(),
+			HTTPVersion: req.Proto,
+			Headers:     h.headers(req.Header),
+			QueryString: harQuery(req.URL.Query()),
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(payload),
+		},
+		Response: harResponse{
+			Headers:     []harNameValue{},
+			Cookies:     []harNameValue{},
+			HeadersSize: -1,
+			Content:     harContent{},
+		},
+		Cache:   struct{}{},
+		Timings: harTimings{Send: 0, Wait: milliseconds(elapsed), Receive: 0},
+	}
+	if len(payload) > 0 {
+		e.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(payload)}
+	}
+	if resp != nil {
+		e.Response.Status = resp.StatusCode
+		e.Response.StatusText = http.StatusText(resp.StatusCode)
+		e.Response.HTTPVersion = resp.Proto
+		e.Response.Headers = h.headers(resp.Header)
+		e.Response.BodySize = len(body)
+		e.Response.Content = harContent{Size: len(body), MimeType: resp.Header.Get("Content-Type"), Text: string(body)}
+	}
+
+	h.mu.Lock()
+	h.entries = append(h.entries, e)
+	h.mu.Unlock()
+}
+
+func (h *harRecorder) headers(header http.Header) []harNameValue {
+	names := []string{}
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []harNameValue{}
+	for _, name := range names {
+		for _, v := range header[name] {
+			if strings.EqualFold(name, "Authorization") && !h.includeToken {
+				v = "Bearer " + tokenPlaceholder
+			}
+			headers = append(headers, harNameValue{Name: name, Value: v})
+		}
+	}
+	return headers
+}
+
+// save appends the recorded entries to the HAR archive in filename, creating it if it doesn't exist
+func (h *harRecorder) save(filename string) error {
+	if h == nil || filename == "" {
+		return nil
+	}
+
+	archive := harArchive{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "twitch-cli", Version: util.GetVersion()},
+		Entries: []harEntry{},
+	}}
+	b, err := os.ReadFile(filename)
+	if err == nil && len(strings.TrimSpace(string(b))) > 0 {
+		if err := json.Unmarshal(b, &archive); err != nil {
+			return fmt.Errorf("Error reading HAR archive %v: %v", filename, err)
+		}
+	} else if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	h.mu.Lock()
+	archive.Log.Entries = append(archive.Log.Entries, h.entries...)
+	h.entries = nil
+	h.mu.Unlock()
+
+	b, err = json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+func harQuery(q url.Values) []harNameValue {
+	names := []string{}
+	for name := range q {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	query := []harNameValue{}
+	for _, name := range names {
+		for _, v := range q[name] {
+			query = append(query, harNameValue{Name: name, Value: v})
+		}
+	}
+	return query
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// HAR 1.2, as described at http://www.softwareishard.com/blog/har-12-spec/
+type harArchive struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestCurlCommand(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	client := clientInformation{ClientID: "1111", Token: "4567"}
+
+	c := curlCommand("GET", "https://api.twitch.tv/helix/users?login=twitchdev", nil, client, false)
+	a.Equal(`curl -X GET 'https://api.twitch.tv/helix/users?login=twitchdev' \
+  -H 'Client-ID: 1111' \
+  -H "Authorization: Bearer $TWITCH_TOKEN"`, c)
+	a.NotContains(c, "4567")
+
+	c = curlCommand("PATCH", "https://api.twitch.tv/helix/channels?broadcaster_id=1", []byte(`{"title":"it's live"}`), client, true)
+	a.Equal(`curl -X PATCH 'https://api.twitch.tv/helix/channels?broadcaster_id=1' \
+  -H 'Client-ID: 1111' \
+  -H 'Authorization: Bearer 4567' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"title":"it'\''s live"}'`, c)
+}
+
+func TestNewRequestExport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	defer viper.Set("BASE_URL", "")
+
+	// exported requests aren't sent
+	err := NewRequest(RequestParameters{Method: "GET", Path: "/users", QueryParameters: []string{"login=twitchdev"}, Export: ExportCurl})
+	a.Nil(err)
+	a.Equal(0, requests)
+
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users", Export: "wget"})
+	a.ErrorContains(err, "Invalid export format wget")
+}
+
+func TestNewRequestHAR(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("login") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"Not Found","status":404,"message":""}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"id":"1"}]}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("BASE_URL", ts.URL)
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	defer viper.Set("BASE_URL", "")
+
+	harFile := filepath.Join(t.TempDir(), "requests.har")
+	err := NewRequest(RequestParameters{Method: "GET", Path: "/users", QueryParameters: []string{"login=twitchdev"}, HARFile: harFile})
+	a.Nil(err)
+
+	// failed requests are appended too
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users", QueryParameters: []string{"login=missing"}, HARFile: harFile})
+	a.NotNil(err)
+
+	b, err := os.ReadFile(harFile)
+	a.Nil(err)
+	a.NotContains(string(b), "4567")
+
+	var archive harArchive
+	a.Nil(json.Unmarshal(b, &archive))
+	a.Equal("1.2", archive.Log.Version)
+	a.Equal("twitch-cli", archive.Log.Creator.Name)
+	a.Len(archive.Log.Entries, 2)
+
+	e := archive.Log.Entries[0]
+	a.Equal("GET", e.Request.Method)
+	a.Equal(ts.URL+"/users?login=twitchdev", e.Request.URL)
+	a.Equal([]harNameValue{{Name: "login", Value: "twitchdev"}}, e.Request.QueryString)
+	a.Contains(e.Request.Headers, harNameValue{Name: "Authorization", Value: "Bearer $TWITCH_TOKEN"})
+	a.Contains(e.Request.Headers, harNameValue{Name: "Client-Id", Value: "1111"})
+	a.Equal(200, e.Response.Status)
+	a.Equal("application/json", e.Response.Content.MimeType)
+	a.JSONEq(`{"data":[{"id":"1"}]}`, e.Response.Content.Text)
+	a.Equal(404, archive.Log.Entries[1].Response.Status)
+
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users", HARFile: harFile, IncludeToken: true})
+	a.Nil(err)
+	b, err = os.ReadFile(harFile)
+	a.Nil(err)
+	a.Nil(json.Unmarshal(b, &archive))
+	a.Len(archive.Log.Entries, 3)
+	a.Contains(archive.Log.Entries[2].Request.Headers, harNameValue{Name: "Authorization", Value: "Bearer 4567"})
+
+	// batches record every request
+	input := `{"path":"/users","query":{"login":"a"}}
+{"path":"/users","query":{"login":"b"}}`
+	err = RunBatch(BatchParameters{Input: strings.NewReader(input), Output: &strings.Builder{}, Concurrency: 2, HARFile: harFile})
+	a.Nil(err)
+	b, err = os.ReadFile(harFile)
+	a.Nil(err)
+	a.Nil(json.Unmarshal(b, &archive))
+	a.Len(archive.Log.Entries, 5)
+
+	// archives that can't be read aren't overwritten
+	a.Nil(os.WriteFile(harFile, []byte("potato"), 0600))
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users", HARFile: harFile})
+	a.ErrorContains(err, "Error saving HAR archive")
+	b, err = os.ReadFile(harFile)
+	a.Nil(err)
+	a.Equal("potato", string(b))
+}
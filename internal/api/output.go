@@ -0,0 +1,388 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/TylerBrock/colorjson"
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputJSON   = "json"
+	OutputNDJSON = "ndjson"
+	OutputCSV    = "csv"
+	OutputTable  = "table"
+	OutputYAML   = "yaml"
+)
+
+// OutputFormats are the values accepted for RequestParameters.Output
+var OutputFormats = []string{OutputJSON, OutputNDJSON, OutputCSV, OutputTable, OutputYAML}
+
+// field is one path from a --fields expression, e.g. `.broadcaster.login` or `tags[0]`
+type field struct {
+	name string
+	path []string
+}
+
+// parseFields parses a comma separated list of jq-style paths, each selecting a value from every item in data[]
+func parseFields(expression string) ([]field, error) {
+	fields := []field{}
+	if strings.TrimSpace(expression) == "" {
+		return fields, nil
+	}
+
+	for _, f := range strings.Split(expression, ",") {
+		name := strings.TrimPrefix(strings.TrimSpace(f), ".")
+		if name == "" {
+			return nil, fmt.Errorf("Invalid field expression %q", expression)
+		}
+
+		path := []string{}
+		for _, part := range strings.Split(strings.ReplaceAll(name, "[", ".["), ".") {
+			if part == "" {
+				continue
+			}
+			if strings.HasPrefix(part, "[") {
+				index := strings.TrimSuffix(strings.TrimPrefix(part, "["), "]")
+				if _, err := strconv.Atoi(index); err != nil || !strings.HasSuffix(part, "]") {
+					return nil, fmt.Errorf("Invalid array index in field %q", name)
+				}
+				part = "[" + index + "]"
+			}
+			path = append(path, part)
+		}
+		fields = append(fields, field{name: name, path: path})
+	}
+	return fields, nil
+}
+
+func (f field) get(item interface{}) interface{} {
+	v := item
+	for _, part := range f.path {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			v = t[part]
+		case []interface{}:
+			i, err := strconv.Atoi(strings.Trim(part, "[]"))
+			if err != nil || i < 0 || i >= len(t) {
+				return nil
+			}
+			v = t[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// dataItems returns the items in a response's data, treating data that isn't an array (e.g. schedules) as a single item
+func dataItems(data interface{}) []interface{} {
+	switch d := data.(type) {
+	case nil:
+		return []interface{}{}
+	case []interface{}:
+		return d
+	default:
+		return []interface{}{d}
+	}
+}
+
+// selectFields returns each item reduced to the selected fields; items are returned unchanged if no fields are selected
+func selectFields(items []interface{}, fields []field) []interface{} {
+	if len(fields) == 0 {
+		return items
+	}
+
+	selected := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		s := map[string]interface{}{}
+		for _, f := range fields {
+			s[f.name] = f.get(item)
+		}
+		selected = append(selected, s)
+	}
+	return selected
+}
+
+// columns returns the column names for tabular output: the selected fields in order, or every top-level key sorted
+func columns(items []interface{}, fields []field) []string {
+	names := []string{}
+	if len(fields) > 0 {
+		for _, f := range fields {
+			names = append(names, f.name)
+		}
+		return names
+	}
+
+	seen := map[string]bool{}
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			for k := range m {
+				if !seen[k] {
+					seen[k] = true
+					names = append(names, k)
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cell formats a value for CSV or table output; objects and arrays are written as JSON
+func cell(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(t)
+		return string(b)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func row(item interface{}, names []string) []string {
+	m, _ := item.(map[string]interface{})
+	r := make([]string, 0, len(names))
+	for _, n := range names {
+		r = append(r, cell(m[n]))
+	}
+	return r
+}
+
+func writeNDJSON(w io.Writer, items []interface{}) error {
+	for _, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("Error marshalling json: %v", err)
+		}
+		if _, err := fmt.Fprintln(w, string(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, items []interface{}, fields []field) error {
+	names := columns(items, fields)
+	c := csv.NewWriter(w)
+	if err := c.Write(names); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := c.Write(row(item, names)); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+func writeTable(w io.Writer, items []interface{}, fields []field) error {
+	names := columns(items, fields)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	headers := make([]string, 0, len(names))
+	for _, n := range names {
+		headers = append(headers, strings.ToUpper(n))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, item := range items {
+		// tabs and newlines in values would break the columns
+		r := row(item, names)
+		for i := range r {
+			r[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(r[i])
+		}
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	if err := e.Encode(v); err != nil {
+		return fmt.Errorf("Error marshalling yaml: %v", err)
+	}
+	return e.Close()
+}
+
+// pageWriter prints an autopaginated response a page at a time, so pages aren't lost if a later one fails
+type pageWriter interface {
+	writePage(items []interface{}) error
+	// close finishes the output; cursor is the cursor for the next page, if there is one
+	close(cursor string) error
+}
+
+func newPageWriter(w io.Writer, output string, prettyPrint bool, fields []field) pageWriter {
+	switch output {
+	case OutputNDJSON:
+		return &ndjsonPageWriter{w: w}
+	case OutputCSV:
+		return &csvPageWriter{w: csv.NewWriter(w), fields: fields}
+	default:
+		return &jsonPageWriter{w: w, prettyPrint: prettyPrint}
+	}
+}
+
+type ndjsonPageWriter struct {
+	w io.Writer
+}
+
+func (p *ndjsonPageWriter) writePage(items []interface{}) error { return writeNDJSON(p.w, items) }
+
+func (p *ndjsonPageWriter) close(cursor string) error { return nil }
+
+// csvPageWriter writes the header with the first page, so columns come from --fields or the first page's keys
+type csvPageWriter struct {
+	w       *csv.Writer
+	fields  []field
+	columns []string
+}
+
+func (p *csvPageWriter) writePage(items []interface{}) error {
+	if p.columns == nil {
+		// wait for a page with items to take the columns from
+		if len(items) == 0 && len(p.fields) == 0 {
+			return nil
+		}
+		p.columns = columns(items, p.fields)
+		if err := p.w.Write(p.columns); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if err := p.w.Write(row(item, p.columns)); err != nil {
+			return err
+		}
+	}
+	p.w.Flush()
+	return p.w.Error()
+}
+
+func (p *csvPageWriter) close(cursor string) error { return nil }
+
+// jsonPageWriter writes the same document as the buffered JSON output: every item in data, then the pagination cursor
+type jsonPageWriter struct {
+	w           io.Writer
+	prettyPrint bool
+	count       int
+	closed      bool
+}
+
+func (p *jsonPageWriter) marshal(v interface{}) ([]byte, error) {
+	if !p.prettyPrint {
+		return json.Marshal(v)
+	}
+	// since Command Prompt/Powershell don't support coloring, will pretty print without colors
+	if runtime.GOOS == "windows" {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	return jsonFormatter().Marshal(v)
+}
+
+func (p *jsonPageWriter) key(name string) string {
+	if p.prettyPrint && runtime.GOOS != "windows" {
+		return jsonFormatter().KeyColor.Sprintf("\"%s\": ", name)
+	}
+	if p.prettyPrint {
+		return fmt.Sprintf("\"%s\": ", name)
+	}
+	return fmt.Sprintf("\"%s\":", name)
+}
+
+// indent indents a pretty printed value to the given depth
+func (p *jsonPageWriter) indent(b []byte, depth int) string {
+	if !p.prettyPrint {
+		return string(b)
+	}
+	return strings.ReplaceAll(string(b), "\n", "\n"+strings.Repeat("  ", depth))
+}
+
+func (p *jsonPageWriter) writePage(items []interface{}) error {
+	var b strings.Builder
+	for _, item := range items {
+		if p.count == 0 {
+			b.WriteString("{")
+			if p.prettyPrint {
+				b.WriteString("\n  ")
+			}
+			b.WriteString(p.key("data") + "[")
+		} else {
+			b.WriteString(",")
+		}
+		if p.prettyPrint {
+			b.WriteString("\n    ")
+		}
+
+		s, err := p.marshal(item)
+		if err != nil {
+			return fmt.Errorf("Error marshalling json: %v", err)
+		}
+		b.WriteString(p.indent(s, 2))
+		p.count++
+	}
+
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+func (p *jsonPageWriter) close(cursor string) error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	var b strings.Builder
+	if p.count == 0 {
+		b.WriteString("{")
+		if p.prettyPrint {
+			b.WriteString("\n  ")
+		}
+		b.WriteString(p.key("data") + "[")
+	} else if p.prettyPrint {
+		b.WriteString("\n  ")
+	}
+	b.WriteString("],")
+	if p.prettyPrint {
+		b.WriteString("\n  ")
+	}
+
+	pagination := map[string]interface{}{}
+	if cursor != "" {
+		pagination["cursor"] = cursor
+	}
+	s, err := p.marshal(pagination)
+	if err != nil {
+		return fmt.Errorf("Error marshalling json: %v", err)
+	}
+	b.WriteString(p.key("pagination") + p.indent(s, 1))
+	if p.prettyPrint {
+		b.WriteString("\n")
+	}
+	b.WriteString("}\n")
+
+	_, err = io.WriteString(p.w, b.String())
+	return err
+}
+
+func jsonFormatter() *colorjson.Formatter {
+	f := colorjson.NewFormatter()
+	f.Indent = 2
+	f.KeyColor = color.New(color.FgBlue).Add(color.Bold)
+	return f
+}
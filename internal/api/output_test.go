@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func testItems(a *assert.Assertions) []interface{} {
+	var items []interface{}
+	err := json.Unmarshal([]byte(`[
+		{"id":"1","login":"one","tags":["a","b"],"broadcaster":{"login":"caster"}},
+		{"id":"2","login":"two","description":"tab\tand\nnewline"}
+	]`), &items)
+	a.Nil(err)
+	return items
+}
+
+func TestParseFields(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	fields, err := parseFields(".id, login,.broadcaster.login,tags[1]")
+	a.Nil(err)
+	a.Len(fields, 4)
+	a.Equal("id", fields[0].name)
+	a.Equal([]string{"broadcaster", "login"}, fields[2].path)
+	a.Equal([]string{"tags", "[1]"}, fields[3].path)
+
+	items := testItems(a)
+	a.Equal("caster", fields[2].get(items[0]))
+	a.Equal("b", fields[3].get(items[0]))
+	a.Nil(fields[2].get(items[1]))
+	a.Nil(fields[3].get(items[1]))
+
+	fields, err = parseFields("")
+	a.Nil(err)
+	a.Empty(fields)
+
+	_, err = parseFields("id,,login")
+	a.NotNil(err)
+	_, err = parseFields("tags[a]")
+	a.NotNil(err)
+}
+
+func TestWriteOutput(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	items := testItems(a)
+	fields, _ := parseFields("login,.broadcaster.login")
+	data := models.APIResponse{Data: items, Pagination: &models.APIPagination{Cursor: "abc"}}
+
+	var b bytes.Buffer
+	a.Nil(writeOutput(&b, OutputNDJSON, data, fields))
+	a.Equal("{\"broadcaster.login\":\"caster\",\"login\":\"one\"}\n{\"broadcaster.login\":null,\"login\":\"two\"}\n", b.String())
+
+	b.Reset()
+	a.Nil(writeOutput(&b, OutputCSV, data, nil))
+	a.Equal("broadcaster,description,id,login,tags\n\"{\"\"login\"\":\"\"caster\"\"}\",,1,one,\"[\"\"a\"\",\"\"b\"\"]\"\n,\"tab\tand\nnewline\",2,two,\n", b.String())
+
+	b.Reset()
+	a.Nil(writeOutput(&b, OutputTable, data, fields))
+	a.Equal("LOGIN  BROADCASTER.LOGIN\none    caster\ntwo    \n", b.String())
+
+	b.Reset()
+	fields, _ = parseFields("id")
+	a.Nil(writeOutput(&b, OutputYAML, data, fields))
+	a.Equal("data:\n  - id: \"1\"\n  - id: \"2\"\npagination:\n  cursor: abc\n", b.String())
+
+	// data that isn't an array is a single item
+	b.Reset()
+	a.Nil(writeOutput(&b, OutputNDJSON, models.APIResponse{Data: map[string]interface{}{"id": "1"}}, nil))
+	a.Equal("{\"id\":\"1\"}\n", b.String())
+}
+
+func TestNewRequestOutput(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	err := NewRequest(RequestParameters{Method: "GET", Path: "/users", Output: "potato"})
+	a.NotNil(err)
+
+	err = NewRequest(RequestParameters{Method: "GET", Path: "/users", Fields: "id,"})
+	a.NotNil(err)
+}
+
+func TestPageWriters(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	items := testItems(a)
+
+	for _, prettyPrint := range []bool{false, true} {
+		var b bytes.Buffer
+		p := newPageWriter(&b, OutputJSON, prettyPrint, nil)
+		a.Nil(p.writePage(items[:1]))
+		a.Nil(p.writePage([]interface{}{}))
+		a.Nil(p.writePage(items[1:]))
+		a.Nil(p.close("next"))
+		a.Nil(p.close("next"))
+
+		var r models.APIResponse
+		a.Nil(json.Unmarshal(b.Bytes(), &r), b.String())
+		a.Equal(items, r.Data)
+		a.Equal("next", r.Pagination.Cursor)
+
+		// no pages still writes a valid, empty response
+		b.Reset()
+		p = newPageWriter(&b, OutputJSON, prettyPrint, nil)
+		a.Nil(p.close(""))
+		r = models.APIResponse{}
+		a.Nil(json.Unmarshal(b.Bytes(), &r), b.String())
+		a.Equal([]interface{}{}, r.Data)
+	}
+
+	var b bytes.Buffer
+	fields, _ := parseFields("id,login")
+	p := newPageWriter(&b, OutputCSV, false, nil)
+	a.Nil(p.writePage([]interface{}{}))
+	a.Nil(p.writePage(selectFields(items[:1], fields)))
+	a.Nil(p.writePage(selectFields(items[1:], fields)))
+	a.Nil(p.close(""))
+	a.Equal("id,login\n1,one\n2,two\n", b.String())
+}
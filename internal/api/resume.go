@@ -0,0 +1,69 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// resumeState is the content of a --resume-file, recording where an autopaginated request got to
+type resumeState struct {
+	Method          string   `json:"method"`
+	Path            string   `json:"path"`
+	QueryParameters []string `json:"query_parameters"`
+	Cursor          string   `json:"cursor"`
+	Pages           int      `json:"pages"`
+}
+
+// readResumeFile returns the state saved for the request, erroring if the file was saved for a different request
+func readResumeFile(path string, p RequestParameters) (resumeState, error) {
+	var s resumeState
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return s, fmt.Errorf("Error reading resume file: %v", err)
+	}
+	if err := json.Unmarshal(content, &s); err != nil {
+		return s, fmt.Errorf("Error reading resume file %v: %v", path, err)
+	}
+
+	query := p.QueryParameters
+	if query == nil {
+		query = []string{}
+	}
+	if s.QueryParameters == nil {
+		s.QueryParameters = []string{}
+	}
+	if s.Method != p.Method || s.Path != p.Path || !reflect.DeepEqual(s.QueryParameters, query) {
+		return s, fmt.Errorf("Resume file %v is for `%v %v` with query parameters %v, not this request", path, s.Method, s.Path, s.QueryParameters)
+	}
+	return s, nil
+}
+
+func writeResumeFile(path string, s resumeState) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write and rename, so the file is never left half written if the CLI is interrupted
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("Error writing resume file: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("Error writing resume file: %v", err)
+	}
+	return nil
+}
+
+func removeResumeFile(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,330 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/login"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// savedRequestsFileName is the file in the application directory that the default profile's saved requests are stored in;
+// other profiles use saved-requests.<profile>.json, like their configuration files
+var savedRequestsFileName = "saved-requests.json"
+
+var validSavedRequestName = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+var validVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// BuiltinVariables are the template variables every saved request can use without --var, describing the token's user.
+// They're looked up with the token's /validate response, only when a request uses them.
+var BuiltinVariables = []string{"me", "login", "client_id"}
+
+// SavedRequest is a named request that can be sent again later. Its path, query parameters and body are Go templates,
+// such as `broadcaster_id={{.me}}`, rendered with Render before it's sent.
+type SavedRequest struct {
+	Name            string   `json:"name"`
+	Method          string   `json:"method"`
+	Path            string   `json:"path"`
+	QueryParameters []string `json:"query_parameters,omitempty"` // in `key=value` format, as given to --query-params
+	Body            string   `json:"body,omitempty"`
+}
+
+// Command returns the `twitch api` command that sends the request
+func (r SavedRequest) Command() string {
+	parts := []string{"twitch", "api", strings.ToLower(r.Method), r.Path}
+	for _, q := range r.QueryParameters {
+		parts = append(parts, "-q", shellQuote(q))
+	}
+	if r.Body != "" {
+		parts = append(parts, "-b", shellQuote(r.Body))
+	}
+	return strings.Join(parts, " ")
+}
+
+// templates returns the parts of the request that are templates
+func (r SavedRequest) templates() []string {
+	return append([]string{r.Path, r.Body}, r.QueryParameters...)
+}
+
+// Variables returns the names of the template variables the request uses, sorted
+func (r SavedRequest) Variables() ([]string, error) {
+	names := map[string]bool{}
+	for _, text := range r.templates() {
+		t, err := template.New(r.Name).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing template %v: %v", text, err)
+		}
+		templateFields(t.Tree.Root, names)
+	}
+
+	variables := []string{}
+	for name := range names {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+	return variables, nil
+}
+
+// templateFields collects the names of the fields of . used by a template, such as me in {{.me}}
+func templateFields(node parse.Node, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateFields(child, names)
+		}
+	case *parse.ActionNode:
+		templateFields(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			templateFields(cmd, names)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			templateFields(arg, names)
+		}
+	case *parse.ChainNode:
+		templateFields(n.Node, names)
+	case *parse.FieldNode:
+		names[n.Ident[0]] = true
+	case *parse.IfNode:
+		templateFields(&n.BranchNode, names)
+	case *parse.RangeNode:
+		templateFields(&n.BranchNode, names)
+	case *parse.WithNode:
+		templateFields(&n.BranchNode, names)
+	case *parse.BranchNode:
+		templateFields(n.Pipe, names)
+		templateFields(n.List, names)
+		templateFields(n.ElseList, names)
+	}
+}
+
+// Render returns the request with its templates executed. vars take precedence over BuiltinVariables, which are only looked up if they're used.
+func (r SavedRequest) Render(vars map[string]string) (SavedRequest, error) {
+	names, err := r.Variables()
+	if err != nil {
+		return r, err
+	}
+
+	// missing variables are reported before the token is validated
+	for _, name := range names {
+		if _, ok := vars[name]; !ok && !isBuiltinVariable(name) {
+			return r, fmt.Errorf("%v uses the variable %v; pass it with --var %v=<value>", r.Name, name, name)
+		}
+	}
+
+	data := map[string]string{}
+	var identity *login.ValidateResponse
+	for _, name := range names {
+		if v, ok := vars[name]; ok {
+			data[name] = v
+			continue
+		}
+		if identity == nil {
+			v, err := validateToken()
+			if err != nil {
+				return r, fmt.Errorf("Error looking up {{.%v}}: %v", name, err)
+			}
+			identity = &v
+		}
+		switch name {
+		case "me":
+			data[name] = identity.UserID
+		case "login":
+			data[name] = identity.UserLogin
+		case "client_id":
+			data[name] = identity.ClientID
+		}
+		if data[name] == "" {
+			return r, fmt.Errorf("{{.%v}} needs a user access token; pass --var %v=<value> to use an app access token", name, name)
+		}
+	}
+
+	rendered := r
+	execute := func(text string) (string, error) {
+		t, err := template.New(r.Name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", err
+		}
+		var b bytes.Buffer
+		if err := t.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("Error rendering %v: %v", text, err)
+		}
+		return b.String(), nil
+	}
+	if rendered.Path, err = execute(r.Path); err != nil {
+		return r, err
+	}
+	if rendered.Body, err = execute(r.Body); err != nil {
+		return r, err
+	}
+	rendered.QueryParameters = make([]string, len(r.QueryParameters))
+	for i, q := range r.QueryParameters {
+		if rendered.QueryParameters[i], err = execute(q); err != nil {
+			return r, err
+		}
+	}
+	return rendered, nil
+}
+
+func isBuiltinVariable(name string) bool {
+	for _, b := range BuiltinVariables {
+		if b == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validateToken returns the /validate response for the token in the active profile
+func validateToken() (login.ValidateResponse, error) {
+	client, err := GetClientInformation()
+	if err != nil {
+		return login.ValidateResponse{}, err
+	}
+	return login.ValidateCredentials(login.LoginParameters{
+		Token: client.Token,
+		URL:   login.WithBaseURL(login.ValidateTokenURL, viper.GetString("AUTH_BASE_URL")),
+	})
+}
+
+// ParseVariables parses variables given as `key=value`, as with --var
+func ParseVariables(values []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || !validVariableName.MatchString(name) {
+			return nil, fmt.Errorf("Invalid variable %v. Variables are given as name=value, and names may only contain letters, numbers and underscores", v)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func savedRequestsPath() (string, error) {
+	home, err := util.GetApplicationDir()
+	if err != nil {
+		return "", err
+	}
+	if viper.GetString("SAVED_REQUESTS_FILENAME") != "" {
+		savedRequestsFileName = viper.GetString("SAVED_REQUESTS_FILENAME")
+	}
+
+	// saved requests often use IDs from one profile's account, so each profile has its own
+	name := savedRequestsFileName
+	if profile := util.GetProfile(); profile != util.DefaultProfile {
+		name = strings.TrimSuffix(name, ".json") + "." + profile + ".json"
+	}
+	return filepath.Join(home, name), nil
+}
+
+// SavedRequests returns every saved request, sorted by name
+func SavedRequests() ([]SavedRequest, error) {
+	path, err := savedRequestsPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []SavedRequest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	requests := []SavedRequest{}
+	if err := json.Unmarshal(b, &requests); err != nil {
+		return nil, fmt.Errorf("Error reading saved requests from %v: %v", path, err)
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].Name < requests[j].Name })
+	return requests, nil
+}
+
+// GetSavedRequest returns the saved request called name
+func GetSavedRequest(name string) (SavedRequest, error) {
+	requests, err := SavedRequests()
+	if err != nil {
+		return SavedRequest{}, err
+	}
+	for _, r := range requests {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return SavedRequest{}, fmt.Errorf("No saved request called %v", name)
+}
+
+// SaveRequest saves a request, replacing any saved request with the same name
+func SaveRequest(r SavedRequest) error {
+	if !validSavedRequestName.MatchString(r.Name) {
+		return fmt.Errorf("Invalid name %v. Names may only contain letters, numbers, dots, dashes and underscores", r.Name)
+	}
+	if _, err := r.Variables(); err != nil {
+		return err
+	}
+	r.Method = strings.ToUpper(r.Method)
+
+	requests, err := SavedRequests()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range requests {
+		if requests[i].Name == r.Name {
+			requests[i] = r
+			replaced = true
+		}
+	}
+	if !replaced {
+		requests = append(requests, r)
+	}
+	return writeSavedRequests(requests)
+}
+
+// DeleteSavedRequest removes the saved request called name
+func DeleteSavedRequest(name string) error {
+	requests, err := SavedRequests()
+	if err != nil {
+		return err
+	}
+	for i, r := range requests {
+		if r.Name == name {
+			return writeSavedRequests(append(requests[:i], requests[i+1:]...))
+		}
+	}
+	return fmt.Errorf("No saved request called %v", name)
+}
+
+func writeSavedRequests(requests []SavedRequest) error {
+	sort.Slice(requests, func(i, j int) bool { return requests[i].Name < requests[j].Name })
+
+	path, err := savedRequestsPath()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestSavedRequests(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	viper.Set("SAVED_REQUESTS_FILENAME", "test-saved-requests.json")
+	path, err := savedRequestsPath()
+	a.Nil(err)
+	os.Remove(path)
+	defer os.Remove(path)
+
+	requests, err := SavedRequests()
+	a.Nil(err)
+	a.Empty(requests)
+
+	a.Nil(SaveRequest(SavedRequest{Name: "users", Method: "get", Path: "/users", QueryParameters: []string{"login=twitchdev"}}))
+	a.Nil(SaveRequest(SavedRequest{Name: "title", Method: "PATCH", Path: "/channels", QueryParameters: []string{"broadcaster_id=1"}, Body: `{"title":"it's live"}`}))
+	a.ErrorContains(SaveRequest(SavedRequest{Name: "two words", Method: "GET", Path: "/users"}), "Invalid name")
+
+	requests, err = SavedRequests()
+	a.Nil(err)
+	a.Len(requests, 2)
+	a.Equal("title", requests[0].Name)
+	a.Equal("GET", requests[1].Method)
+
+	// saving under an existing name replaces it
+	a.Nil(SaveRequest(SavedRequest{Name: "users", Method: "GET", Path: "/users", QueryParameters: []string{"login=twitch"}}))
+	r, err := GetSavedRequest("users")
+	a.Nil(err)
+	a.Equal([]string{"login=twitch"}, r.QueryParameters)
+	requests, err = SavedRequests()
+	a.Nil(err)
+	a.Len(requests, 2)
+
+	_, err = GetSavedRequest("potato")
+	a.ErrorContains(err, "No saved request called potato")
+
+	r, err = GetSavedRequest("title")
+	a.Nil(err)
+	a.Equal(`twitch api patch /channels -q 'broadcaster_id=1' -b '{"title":"it'\''s live"}'`, r.Command())
+
+	// each profile has its own saved requests
+	a.Nil(util.SetProfile("mock"))
+	defer util.SetProfile(util.DefaultProfile)
+	mockPath, err := savedRequestsPath()
+	a.Nil(err)
+	a.NotEqual(path, mockPath)
+	defer os.Remove(mockPath)
+
+	requests, err = SavedRequests()
+	a.Nil(err)
+	a.Empty(requests)
+	a.Nil(SaveRequest(SavedRequest{Name: "users", Method: "GET", Path: "/users"}))
+	a.Nil(DeleteSavedRequest("users"))
+
+	a.Nil(util.SetProfile(util.DefaultProfile))
+	_, err = GetSavedRequest("users")
+	a.Nil(err)
+}
+
+func TestSavedRequestRender(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	validations := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		validations++
+		a.Equal("/auth/validate", r.URL.Path)
+		if r.Header.Get("Authorization") == "OAuth app" {
+			w.Write([]byte(`{"client_id":"1111","scopes":[],"expires_in":100}`))
+			return
+		}
+		w.Write([]byte(`{"client_id":"1111","login":"twitchdev","user_id":"141981764","scopes":[],"expires_in":100}`))
+	}))
+	defer ts.Close()
+
+	viper.Set("AUTH_BASE_URL", ts.URL+"/auth")
+	viper.Set("clientid", "1111")
+	viper.Set("accesstoken", "4567")
+	viper.Set("tokenexpiration", "0")
+	defer viper.Set("AUTH_BASE_URL", "")
+
+	r := SavedRequest{
+		Name:            "title",
+		Method:          "PATCH",
+		Path:            "/channels",
+		QueryParameters: []string{"broadcaster_id={{.me}}"},
+		Body:            `{"title":"{{.title}} with {{.login}}"}`,
+	}
+	vars, err := r.Variables()
+	a.Nil(err)
+	a.Equal([]string{"login", "me", "title"}, vars)
+
+	_, err = r.Render(map[string]string{})
+	a.ErrorContains(err, "pass it with --var title=<value>")
+
+	rendered, err := r.Render(map[string]string{"title": "Speedrunning"})
+	a.Nil(err)
+	a.Equal([]string{"broadcaster_id=141981764"}, rendered.QueryParameters)
+	a.Equal(`{"title":"Speedrunning with twitchdev"}`, rendered.Body)
+	a.Equal(1, validations)
+
+	// variables take precedence over built-ins, which aren't looked up unless they're needed
+	rendered, err = r.Render(map[string]string{"title": "Speedrunning", "me": "1", "login": "someone"})
+	a.Nil(err)
+	a.Equal([]string{"broadcaster_id=1"}, rendered.QueryParameters)
+	a.Equal(1, validations)
+
+	rendered, err = SavedRequest{Name: "users", Method: "GET", Path: "/users"}.Render(nil)
+	a.Nil(err)
+	a.Equal("/users", rendered.Path)
+	a.Equal(1, validations)
+
+	// app access tokens have no user
+	viper.Set("accesstoken", "app")
+	defer viper.Set("accesstoken", "4567")
+	_, err = r.Render(map[string]string{"title": "Speedrunning"})
+	a.ErrorContains(err, "needs a user access token")
+
+	a.ErrorContains(SaveRequest(SavedRequest{Name: "broken", Method: "GET", Path: "/users", QueryParameters: []string{"id={{.me"}}), "Error parsing template")
+
+	vars2, err := ParseVariables([]string{"title=a=b", "game_id="})
+	a.Nil(err)
+	a.Equal(map[string]string{"title": "a=b", "game_id": ""}, vars2)
+	_, err = ParseVariables([]string{"title"})
+	a.ErrorContains(err, "Invalid variable title")
+	_, err = ParseVariables([]string{"game-id=1"})
+	a.ErrorContains(err, "Invalid variable game-id=1")
+}
@@ -0,0 +1,209 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// parseQueryParameters parses `key=value` query parameters; parameters without a value are given an empty one
+func parseQueryParameters(params []string) url.Values {
+	q := url.Values{}
+	for _, paramStr := range params {
+		var value string
+		param := strings.Split(paramStr, "=")
+		if len(param) == 2 {
+			value = param[1]
+		}
+		q.Add(param[0], value)
+	}
+	return q
+}
+
+// ValidateRequest checks a request against the catalog, returning every problem found.
+// Requests to paths that aren't in the catalog aren't validated.
+func ValidateRequest(method string, path string, query url.Values, body []byte) error {
+	e, ok := GetEndpoint(path)
+	if !ok {
+		return nil
+	}
+
+	method = strings.ToUpper(method)
+	m, ok := e.Methods[method]
+	if !ok {
+		return fmt.Errorf("%v doesn't support %v. Supported methods are: %v", path, method, strings.Join(e.SortedMethods(), ", "))
+	}
+
+	problems := []string{}
+
+	names := []string{}
+	params := map[string]Parameter{}
+	for _, p := range m.Parameters {
+		names = append(names, p.Name)
+		params[p.Name] = p
+	}
+
+	unknown := []string{}
+	for k := range query {
+		if _, ok := params[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown query parameter %v%v", k, didYouMean(k, names)))
+	}
+
+	for _, p := range m.Parameters {
+		values := query[p.Name]
+		if p.Required && len(values) == 0 {
+			problems = append(problems, fmt.Sprintf("missing required query parameter %v", p.Name))
+		}
+		if !p.Repeatable && len(values) > 1 {
+			problems = append(problems, fmt.Sprintf("query parameter %v can only be given once", p.Name))
+		}
+		if p.Repeatable && p.MaxCount > 0 && len(values) > p.MaxCount {
+			problems = append(problems, fmt.Sprintf("query parameter %v can be given at most %v times, got %v", p.Name, p.MaxCount, len(values)))
+		}
+	}
+
+	if len(m.RequireOneOf) > 0 {
+		found := false
+		for _, name := range m.RequireOneOf {
+			if len(query[name]) > 0 {
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("one of the query parameters %v is required", strings.Join(m.RequireOneOf, ", ")))
+		}
+	}
+
+	problems = append(problems, validateBody(m, body)...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid request to %v %v:\n  %v", method, path, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+func validateBody(m EndpointMethod, body []byte) []string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		problems := []string{}
+		for _, f := range m.Body {
+			if f.Required {
+				problems = append(problems, fmt.Sprintf("missing required body field %v", f.Name))
+			}
+		}
+		return problems
+	}
+	if len(m.Body) == 0 {
+		return []string{"this endpoint doesn't accept a body"}
+	}
+
+	var fields map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return []string{fmt.Sprintf("body must be a JSON object: %v", err)}
+	}
+
+	problems := []string{}
+	names := []string{}
+	known := map[string]BodyField{}
+	for _, f := range m.Body {
+		names = append(names, f.Name)
+		known[f.Name] = f
+	}
+
+	keys := []string{}
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f, ok := known[k]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown body field %v%v", k, didYouMean(k, names)))
+			continue
+		}
+		if !hasType(fields[k], f.Type) {
+			problems = append(problems, fmt.Sprintf("body field %v must be of type %v", k, f.Type))
+		}
+	}
+	for _, f := range m.Body {
+		if _, ok := fields[f.Name]; f.Required && !ok {
+			problems = append(problems, fmt.Sprintf("missing required body field %v", f.Name))
+		}
+	}
+	return problems
+}
+
+// hasType returns whether a decoded JSON value matches a catalog type; null is accepted for any type
+func hasType(v interface{}, t string) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == TypeString
+	case bool:
+		return t == TypeBoolean
+	case json.Number:
+		if _, err := v.Int64(); err == nil && t == TypeInteger {
+			return true
+		}
+		return t == TypeNumber
+	case []interface{}:
+		return t == TypeArray
+	case map[string]interface{}:
+		return t == TypeObject
+	}
+	return false
+}
+
+// didYouMean suggests the closest of names to a misspelt name, if one is close enough
+func didYouMean(name string, names []string) string {
+	best := ""
+	bestDistance := 3
+	for _, n := range names {
+		if d := levenshtein(strings.ToLower(name), n); d < bestDistance {
+			best = n
+			bestDistance = d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %v?)", best)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -8,25 +8,57 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
 type AuthenticationClient struct {
-	ID          string `db:"id"`
-	Secret      string `db:"secret"`
-	Name        string `db:"name"`
-	IsExtension bool   `db:"is_extension"`
+	ID            string `db:"id"`
+	Secret        string `db:"secret"`
+	Name          string `db:"name"`
+	IsExtension   bool   `db:"is_extension"`
+	TokenLifetime int    `db:"token_lifetime"` // lifetime of issued tokens in seconds; 0 uses the default
 }
 
 type Authorization struct {
-	ID        int    `db:"id" dbi:"false"`
-	ClientID  string `db:"client_id"`
-	UserID    string `db:"user_id"`
-	Token     string `db:"token"`
-	ExpiresAt string `db:"expires_at"`
-	Scopes    string `db:"scopes"`
+	ID           int    `db:"id" dbi:"false"`
+	ClientID     string `db:"client_id"`
+	UserID       string `db:"user_id"`
+	Token        string `db:"token"`
+	ExpiresAt    string `db:"expires_at"`
+	Scopes       string `db:"scopes"`
+	RefreshToken string `db:"refresh_token"`
+	Claims       string `db:"claims"` // OIDC claims parameter requested with the token, as JSON
+}
+
+type AuthorizationCode struct {
+	Code        string `db:"code"`
+	ClientID    string `db:"client_id"`
+	UserID      string `db:"user_id"`
+	RedirectURI string `db:"redirect_uri"`
+	Scopes      string `db:"scopes"`
+	ExpiresAt   string `db:"expires_at"`
+	Nonce       string `db:"nonce"`
+	Claims      string `db:"claims"`
+}
+
+const (
+	DeviceCodeStatusPending  = "pending"
+	DeviceCodeStatusApproved = "approved"
+	DeviceCodeStatusDenied   = "denied"
+)
+
+type DeviceCode struct {
+	DeviceCode   string `db:"device_code"`
+	UserCode     string `db:"user_code"`
+	ClientID     string `db:"client_id"`
+	Scopes       string `db:"scopes"`
+	UserID       string `db:"user_id"`
+	Status       string `db:"status"`
+	ExpiresAt    string `db:"expires_at"`
+	LastPolledAt string `db:"last_polled_at"`
 }
 
 func (q *Query) GetAuthorizationByToken(token string) (Authorization, error) {
@@ -43,6 +75,53 @@ func (q *Query) GetAuthorizationByToken(token string) (Authorization, error) {
 	return r, err
 }
 
+func (q *Query) GetAuthorizationByRefreshToken(refreshToken string) (Authorization, error) {
+	var r Authorization
+
+	err := q.DB.Get(&r, "select * from authorizations where refresh_token = $1 and refresh_token != ''", refreshToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r, nil
+	}
+
+	return r, err
+}
+
+// GetAuthorizations returns all authorizations matching the non-empty fields of a, ordered by creation
+func (q *Query) GetAuthorizations(a Authorization) ([]Authorization, error) {
+	r := []Authorization{}
+	rows, err := q.DB.NamedQuery(generateSQL("select * from authorizations", a, SEP_AND)+" order by id", a)
+	if err != nil {
+		return r, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a Authorization
+		err := rows.StructScan(&a)
+		if err != nil {
+			return r, err
+		}
+		r = append(r, a)
+	}
+
+	return r, nil
+}
+
+func (q *Query) UpdateAuthorizationExpiration(id int, expiresAt string) error {
+	_, err := q.DB.Exec("update authorizations set expires_at = $1 where id = $2", expiresAt, id)
+	return err
+}
+
+func (q *Query) DeleteAuthorization(id int) error {
+	_, err := q.DB.Exec("delete from authorizations where id = $1", id)
+	return err
+}
+
+func (q *Query) UpdateAuthenticationClientTokenLifetime(clientID string, tokenLifetime int) error {
+	_, err := q.DB.Exec("update clients set token_lifetime = $1 where id = $2", tokenLifetime, clientID)
+	return err
+}
+
 func (q *Query) InsertOrUpdateAuthenticationClient(client AuthenticationClient, upsert bool) (AuthenticationClient, error) {
 	db := q.DB
 
@@ -66,7 +145,13 @@ func (q *Query) CreateAuthorization(a Authorization) (Authorization, error) {
 	db := q.DB
 
 	a.Token = generateString(15)
-	a.ExpiresAt = util.GetTimestamp().Add(24 * 30 * time.Hour).Format(time.RFC3339Nano)
+	if a.ExpiresAt == "" {
+		a.ExpiresAt = util.GetTimestamp().Add(24 * 30 * time.Hour).Format(time.RFC3339Nano)
+	}
+	// only user tokens can be refreshed
+	if a.UserID != "" {
+		a.RefreshToken = generateString(30)
+	}
 
 	for {
 		// loop to create unique tokens; likely won't happen, but is worth handling regardless
@@ -86,6 +171,111 @@ func (q *Query) CreateAuthorization(a Authorization) (Authorization, error) {
 	}
 }
 
+// RotateAuthorization replaces the authorization with the given ID with a new one in a single transaction, so the previous token
+// stops working exactly when the new one is issued. If the previous authorization no longer exists, no authorization is created and
+// the returned authorization has no token.
+func (q *Query) RotateAuthorization(previousID int, a Authorization) (Authorization, error) {
+	a.Token = generateString(15)
+	if a.UserID != "" {
+		a.RefreshToken = generateString(30)
+	}
+
+	for {
+		// loop to create unique tokens; likely won't happen, but is worth handling regardless
+		tx := q.DB.MustBegin()
+		res, err := tx.Exec("delete from authorizations where id = $1", previousID)
+		if err != nil {
+			tx.Rollback()
+			return a, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			tx.Rollback()
+			return Authorization{}, err
+		}
+
+		_, err = tx.NamedExec(generateInsertSQL("authorizations", "", a, false), a)
+		if err == nil {
+			return a, tx.Commit()
+		}
+		tx.Rollback()
+		if !strings.Contains(err.Error(), "UNIQUE") {
+			return Authorization{}, err
+		}
+		a.Token = generateString(15)
+	}
+}
+
+func (q *Query) CreateAuthorizationCode(c AuthorizationCode) (AuthorizationCode, error) {
+	c.Code = generateString(30)
+	_, err := q.DB.NamedExec(generateInsertSQL("authorization_codes", "", c, false), c)
+	return c, err
+}
+
+// RedeemAuthorizationCode returns the authorization code and deletes it, so each code can only be exchanged once
+func (q *Query) RedeemAuthorizationCode(code string) (AuthorizationCode, error) {
+	var c AuthorizationCode
+	tx := q.DB.MustBegin()
+
+	err := tx.Get(&c, "select * from authorization_codes where code = $1", code)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return c, nil
+	} else if err != nil {
+		tx.Rollback()
+		return c, err
+	}
+
+	_, err = tx.Exec("delete from authorization_codes where code = $1", code)
+	if err != nil {
+		tx.Rollback()
+		return c, err
+	}
+
+	return c, tx.Commit()
+}
+
+func (q *Query) CreateDeviceCode(d DeviceCode) (DeviceCode, error) {
+	d.Status = DeviceCodeStatusPending
+	for i := 0; ; i++ {
+		// loop to create a unique user code, since they're short enough to be typed by hand
+		d.DeviceCode = generateString(40)
+		d.UserCode = generateUserCode(8)
+
+		_, err := q.DB.NamedExec(generateInsertSQL("device_codes", "", d, false), d)
+		if err == nil || i == 5 {
+			return d, err
+		}
+	}
+}
+
+func (q *Query) GetDeviceCode(deviceCode string) (DeviceCode, error) {
+	var d DeviceCode
+	err := q.DB.Get(&d, "select * from device_codes where device_code = $1", deviceCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return d, nil
+	}
+	return d, err
+}
+
+func (q *Query) GetDeviceCodeByUserCode(userCode string) (DeviceCode, error) {
+	var d DeviceCode
+	err := q.DB.Get(&d, "select * from device_codes where user_code = $1", userCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return d, nil
+	}
+	return d, err
+}
+
+func (q *Query) UpdateDeviceCode(d DeviceCode) error {
+	_, err := q.DB.NamedExec("update device_codes set user_id = :user_id, status = :status, last_polled_at = :last_polled_at where device_code = :device_code", d)
+	return err
+}
+
+func (q *Query) DeleteDeviceCode(deviceCode string) error {
+	_, err := q.DB.Exec("delete from device_codes where device_code = $1", deviceCode)
+	return err
+}
+
 func (q *Query) GetAuthenticationClient(ac AuthenticationClient) (*DBResponse, error) {
 	var r []AuthenticationClient
 	rows, err := q.DB.NamedQuery(generateSQL("select * from clients", ac, SEP_AND)+q.SQL, ac)
@@ -122,3 +312,14 @@ func generateString(length int) string {
 	rand.Read(b)
 	return fmt.Sprintf("%x", b)[:length]
 }
+
+// generateUserCode creates an uppercase code for a user to type in, similar to production's device codes
+func generateUserCode(length int) string {
+	const letters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	b := make([]byte, length)
+	rand.Read(b)
+	for i := range b {
+		b[i] = letters[int(b[i])%len(letters)]
+	}
+	return string(b)
+}
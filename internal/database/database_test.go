@@ -177,6 +177,67 @@ func TestAuthentication(t *testing.T) {
 	authorization, err := q.GetAuthorizationByToken(auth.Token)
 	a.Nil(err)
 	a.Equal(client.ID, authorization.ClientID)
+
+	// only user tokens get refresh tokens
+	a.Empty(auth.RefreshToken)
+	userAuth, err := q.CreateAuthorization(Authorization{ClientID: ac.ID, UserID: "1"})
+	a.Nil(err)
+	a.NotEmpty(userAuth.RefreshToken)
+
+	authorization, err = q.GetAuthorizationByRefreshToken(userAuth.RefreshToken)
+	a.Nil(err)
+	a.Equal(userAuth.Token, authorization.Token)
+
+	authorizations, err := q.GetAuthorizations(Authorization{ClientID: ac.ID, UserID: "1"})
+	a.Nil(err)
+	a.NotEmpty(authorizations)
+	a.Equal(userAuth.Token, authorizations[len(authorizations)-1].Token)
+
+	expiresAt := util.GetTimestamp().Add(-time.Minute).Format(time.RFC3339)
+	a.Nil(q.UpdateAuthorizationExpiration(authorization.ID, expiresAt))
+	authorization, err = q.GetAuthorizationByToken(userAuth.Token)
+	a.Nil(err)
+	a.Equal(expiresAt, authorization.ExpiresAt)
+
+	a.Nil(q.DeleteAuthorization(authorization.ID))
+	authorization, err = q.GetAuthorizationByRefreshToken(userAuth.RefreshToken)
+	a.Nil(err)
+	a.Zero(authorization.ID)
+
+	a.Nil(q.UpdateAuthenticationClientTokenLifetime(ac.ID, 60))
+	dbr, err = q.GetAuthenticationClient(AuthenticationClient{ID: ac.ID})
+	a.Nil(err)
+	a.Equal(60, dbr.Data.([]AuthenticationClient)[0].TokenLifetime)
+
+	device, err := q.CreateDeviceCode(DeviceCode{ClientID: ac.ID, ExpiresAt: util.GetTimestamp().Format(time.RFC3339)})
+	a.Nil(err)
+	a.Len(device.UserCode, 8)
+	a.Equal(DeviceCodeStatusPending, device.Status)
+
+	device.Status = DeviceCodeStatusApproved
+	device.UserID = "1"
+	a.Nil(q.UpdateDeviceCode(device))
+	d, err := q.GetDeviceCodeByUserCode(device.UserCode)
+	a.Nil(err)
+	a.Equal(device, d)
+
+	a.Nil(q.DeleteDeviceCode(device.DeviceCode))
+	d, err = q.GetDeviceCode(device.DeviceCode)
+	a.Nil(err)
+	a.Empty(d.DeviceCode)
+
+	// authorization codes can only be redeemed once
+	code, err := q.CreateAuthorizationCode(AuthorizationCode{ClientID: ac.ID, UserID: "1", RedirectURI: "http://localhost:3000", ExpiresAt: util.GetTimestamp().Format(time.RFC3339)})
+	a.Nil(err)
+	a.NotEmpty(code.Code)
+
+	redeemed, err := q.RedeemAuthorizationCode(code.Code)
+	a.Nil(err)
+	a.Equal(code, redeemed)
+
+	redeemed, err = q.RedeemAuthorizationCode(code.Code)
+	a.Nil(err)
+	a.Empty(redeemed.Code)
 }
 
 func TestAPI(t *testing.T) {
@@ -402,6 +463,52 @@ func TestDrops(t *testing.T) {
 	a.Equal(e.BenefitID, entitlements[0].BenefitID)
 }
 
+func TestExtensions(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	q := db.NewQuery(nil, 100)
+
+	client, err := q.InsertOrUpdateAuthenticationClient(AuthenticationClient{ID: util.RandomClientID(), Name: "extension", IsExtension: true}, false)
+	a.Nil(err)
+
+	e := Extension{ID: client.ID, Version: "1.0.0", Name: "extension", Types: "panel,overlay", CanActivate: true}
+	err = q.InsertExtension(e)
+	a.Nil(err)
+	a.Equal([]string{"panel", "overlay"}, e.GetTypes())
+	a.True(e.SupportsType("overlay"))
+	a.False(e.SupportsType("component"))
+
+	err = q.InstallUserExtension(TEST_USER_ID, e.ID)
+	a.Nil(err)
+	// installing twice is a no-op
+	err = q.InstallUserExtension(TEST_USER_ID, e.ID)
+	a.Nil(err)
+
+	installed, err := q.GetUserInstalledExtensions(TEST_USER_ID)
+	a.Nil(err)
+	a.Len(installed, 1)
+
+	err = q.UpdateUserExtensionSlots(TEST_USER_ID, []UserExtensionSlot{{SlotType: "panel", Slot: "1", ExtensionID: e.ID}}, nil)
+	a.Nil(err)
+
+	// moving the extension to another slot removes it from the first
+	err = q.UpdateUserExtensionSlots(TEST_USER_ID, []UserExtensionSlot{{SlotType: "panel", Slot: "2", ExtensionID: e.ID}}, nil)
+	a.Nil(err)
+
+	slots, err := q.GetUserExtensionSlots(TEST_USER_ID)
+	a.Nil(err)
+	a.Len(slots, 1)
+	a.Equal("2", slots[0].Slot)
+	a.Equal("1.0.0", slots[0].Version)
+
+	err = q.UpdateUserExtensionSlots(TEST_USER_ID, nil, []UserExtensionSlot{{SlotType: "panel", Slot: "2"}})
+	a.Nil(err)
+
+	slots, err = q.GetUserExtensionSlots(TEST_USER_ID)
+	a.Nil(err)
+	a.Len(slots, 0)
+}
+
 func TestModeration(t *testing.T) {
 	a := test_setup.SetupTestEnv(t)
 
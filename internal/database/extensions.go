@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package database
+
+import (
+	"database/sql"
+	"strings"
+)
+
+type Extension struct {
+	ID          string `db:"id" dbs:"e.id" json:"id"`
+	Version     string `db:"version" json:"version"`
+	Name        string `db:"extension_name" json:"name"`
+	Types       string `db:"extension_types" json:"-"`
+	CanActivate bool   `db:"can_activate" json:"can_activate"`
+}
+
+type UserExtensionSlot struct {
+	UserID        string        `db:"user_id"`
+	SlotType      string        `db:"slot_type"`
+	Slot          string        `db:"slot"`
+	ExtensionID   string        `db:"extension_id"`
+	X             sql.NullInt64 `db:"x"`
+	Y             sql.NullInt64 `db:"y"`
+	Version       string        `db:"version" dbi:"false"`
+	ExtensionName string        `db:"extension_name" dbi:"false"`
+}
+
+// GetTypes returns the slot types (panel, overlay, component, mobile) the extension supports
+func (e Extension) GetTypes() []string {
+	types := []string{}
+	for _, t := range strings.Split(e.Types, ",") {
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func (e Extension) SupportsType(slotType string) bool {
+	for _, t := range e.GetTypes() {
+		if t == slotType {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Query) InsertExtension(e Extension) error {
+	_, err := q.DB.NamedExec(generateInsertSQL("extensions", "id", e, false), e)
+	return err
+}
+
+func (q *Query) GetExtensions(e Extension) (*DBResponse, error) {
+	r := []Extension{}
+	rows, err := q.DB.NamedQuery(generateSQL("select * from extensions e", e, SEP_AND)+q.SQL, e)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var e Extension
+		err := rows.StructScan(&e)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, e)
+	}
+
+	dbr := DBResponse{
+		Data:  r,
+		Limit: q.Limit,
+		Total: len(r),
+	}
+
+	if len(r) != q.Limit {
+		q.PaginationCursor = ""
+	}
+
+	dbr.Cursor = q.PaginationCursor
+
+	return &dbr, err
+}
+
+func (q *Query) InstallUserExtension(userID string, extensionID string) error {
+	_, err := q.DB.Exec(`insert into user_extensions (user_id, extension_id) values($1, $2) on conflict do nothing`, userID, extensionID)
+	return err
+}
+
+func (q *Query) GetUserInstalledExtensions(userID string) ([]Extension, error) {
+	r := []Extension{}
+	err := q.DB.Select(&r, `select e.* from extensions e join user_extensions ue on e.id = ue.extension_id where ue.user_id = $1 order by e.extension_name`, userID)
+	return r, err
+}
+
+func (q *Query) GetUserExtensionSlots(userID string) ([]UserExtensionSlot, error) {
+	r := []UserExtensionSlot{}
+	err := q.DB.Select(&r, `select s.*, e.version, e.extension_name from user_extension_slots s join extensions e on s.extension_id = e.id where s.user_id = $1`, userID)
+	return r, err
+}
+
+// UpdateUserExtensionSlots activates the provided slots and deactivates any slot in deactivate, leaving all other slots untouched
+func (q *Query) UpdateUserExtensionSlots(userID string, activate []UserExtensionSlot, deactivate []UserExtensionSlot) error {
+	tx := q.DB.MustBegin()
+	for _, s := range append(activate, deactivate...) {
+		_, err := tx.Exec(`delete from user_extension_slots where user_id = $1 and slot_type = $2 and slot = $3`, userID, s.SlotType, s.Slot)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// an extension can only be active in one slot of a given type at a time
+	for _, s := range activate {
+		_, err := tx.Exec(`delete from user_extension_slots where user_id = $1 and slot_type = $2 and extension_id = $3`, userID, s.SlotType, s.ExtensionID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		s.UserID = userID
+		_, err = tx.NamedExec(generateInsertSQL("user_extension_slots", "", s, false), s)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-const currentVersion = 7
+const currentVersion = 12
 
 type migrateMap struct {
 	SQL     string
@@ -57,6 +57,29 @@ ALTER TABLE users ADD COLUMN content_labels text not null default '';`,
 		SQL:     `ALTER TABLE stream_schedule DROP COLUMN timezone;`,
 		Message: `Removing deprecated stream_schedule.timezone from database`,
 	},
+	8: {
+		SQL: `
+create table extensions ( id text not null primary key, version text not null, extension_name text not null, extension_types text not null, can_activate boolean not null default true, foreign key (id) references clients(id) );
+create table user_extensions ( user_id text not null, extension_id text not null, primary key (user_id, extension_id), foreign key (user_id) references users(id), foreign key (extension_id) references extensions(id) );
+create table user_extension_slots ( user_id text not null, slot_type text not null, slot text not null, extension_id text not null, x int, y int, primary key (user_id, slot_type, slot), foreign key (user_id) references users(id), foreign key (extension_id) references extensions(id) );`,
+		Message: `Adding user extensions tables to database.`,
+	},
+	9: {
+		SQL:     `create table authorization_codes ( code text not null primary key, client_id text not null, user_id text not null, redirect_uri text not null, scopes text, expires_at text not null, foreign key (client_id) references clients(id), foreign key (user_id) references users(id) );`,
+		Message: `Adding authorization codes table to database.`,
+	},
+	10: {
+		SQL:     `alter table authorizations add column refresh_token text not null default ''; alter table clients add column token_lifetime int not null default 0;`,
+		Message: `Adding refresh tokens and token lifetimes to database.`,
+	},
+	11: {
+		SQL:     `create table device_codes ( device_code text not null primary key, user_code text not null unique, client_id text not null, scopes text, user_id text not null default '', status text not null default 'pending', expires_at text not null, last_polled_at text not null default '', foreign key (client_id) references clients(id) );`,
+		Message: `Adding device codes table to database.`,
+	},
+	12: {
+		SQL:     `alter table authorization_codes add column nonce text not null default ''; alter table authorization_codes add column claims text not null default ''; alter table authorizations add column claims text not null default '';`,
+		Message: `Adding OpenID Connect claims to database.`,
+	},
 }
 
 func checkAndUpdate(db sqlx.DB) error {
@@ -116,8 +139,8 @@ create table stream_markers( id text not null primary key, video_id text not nul
 create table video_muted_segments ( video_id text not null, video_offset int not null, duration int not null, primary key (video_id, video_offset), foreign key (video_id) references videos(id) );
 create table subscriptions ( broadcaster_id text not null, user_id text not null, is_gift boolean not null default false, gifter_id text, tier text not null default '1000', created_at text not null, primary key (broadcaster_id, user_id), foreign key (broadcaster_id) references users(id), foreign key (user_id) references users(id), foreign key (gifter_id) references users(id) );
 create table drops_entitlements( id text not null primary key, benefit_id text not null, timestamp text not null, user_id text not null, game_id text not null, status text not null default 'CLAIMED', last_updated text default '2023-01-01T04:17:53.325Z', foreign key (user_id) references users(id), foreign key (game_id) references categories(id) );
-create table clients ( id text not null primary key, secret text not null, is_extension boolean default false, name text not null );
-create table authorizations ( id integer not null primary key AUTOINCREMENT, client_id text not null, user_id text, token text not null unique, expires_at text not null, scopes text, foreign key (client_id) references clients(id) );
+create table clients ( id text not null primary key, secret text not null, is_extension boolean default false, name text not null, token_lifetime int not null default 0 );
+create table authorizations ( id integer not null primary key AUTOINCREMENT, client_id text not null, user_id text, token text not null unique, expires_at text not null, scopes text, refresh_token text not null default '', claims text not null default '', foreign key (client_id) references clients(id) );
 create table polls ( id text not null primary key, broadcaster_id text not null, title text not null, bits_voting_enabled boolean default false, bits_per_vote int default 10, channel_points_voting_enabled boolean default false, channel_points_per_vote int default 10, status text not null, duration int not null, started_at text not null, ended_at text, foreign key (broadcaster_id) references users(id) );
 create table poll_choices ( id text not null primary key, title text not null, votes int not null default 0, channel_points_votes int not null default 0, bits_votes int not null default 0, poll_id text not null, foreign key (poll_id) references polls(id) );
 create table predictions ( id text not null primary key, broadcaster_id text not null, title text not null, winning_outcome_id text, prediction_window int, status text not null, created_at text not null, ended_at text, locked_at text, foreign key (broadcaster_id) references users(id) );
@@ -126,7 +149,12 @@ create table prediction_predictions ( prediction_id text not null, user_id text
 create table clips ( id text not null primary key, broadcaster_id text not null, creator_id text not null, video_id text not null, game_id text not null, title text not null, view_count int default 0, created_at text not null, duration real not null, vod_offset int default 0, foreign key (broadcaster_id) references users(id), foreign key (creator_id) references users(id) );
 create table stream_schedule( id text not null primary key, broadcaster_id text not null, starttime text not null, endtime text not null, is_vacation boolean not null default false, is_recurring boolean not null default false, is_canceled boolean not null default false, title text, category_id text, foreign key(broadcaster_id) references users(id), foreign key (category_id) references categories(id));
 create table chat_settings( broadcaster_id text not null primary key, slow_mode boolean not null default 0, slow_mode_wait_time int not null default 10, follower_mode boolean not null default 0, follower_mode_duration int not null default 60, subscriber_mode boolean not null default 0, emote_mode boolean not null default 0, unique_chat_mode boolean not null default 0, non_moderator_chat_delay boolean not null default 0, non_moderator_chat_delay_duration int not null default 10, shieldmode_is_active boolean not null default 0, shieldmode_moderator_id text not null default '', shieldmode_moderator_login text not null default '', shieldmode_moderator_name text not null default '', shieldmode_last_activated text not null default '' );
-create table vips ( broadcaster_id text not null, user_id text not null, created_at text not null default '', primary key (broadcaster_id, user_id), foreign key (broadcaster_id) references users(id), foreign key (user_id) references users(id) );`
+create table vips ( broadcaster_id text not null, user_id text not null, created_at text not null default '', primary key (broadcaster_id, user_id), foreign key (broadcaster_id) references users(id), foreign key (user_id) references users(id) );
+create table extensions ( id text not null primary key, version text not null, extension_name text not null, extension_types text not null, can_activate boolean not null default true, foreign key (id) references clients(id) );
+create table user_extensions ( user_id text not null, extension_id text not null, primary key (user_id, extension_id), foreign key (user_id) references users(id), foreign key (extension_id) references extensions(id) );
+create table user_extension_slots ( user_id text not null, slot_type text not null, slot text not null, extension_id text not null, x int, y int, primary key (user_id, slot_type, slot), foreign key (user_id) references users(id), foreign key (extension_id) references extensions(id) );
+create table authorization_codes ( code text not null primary key, client_id text not null, user_id text not null, redirect_uri text not null, scopes text, expires_at text not null, nonce text not null default '', claims text not null default '', foreign key (client_id) references clients(id), foreign key (user_id) references users(id) );
+create table device_codes ( device_code text not null primary key, user_code text not null unique, client_id text not null, scopes text, user_id text not null default '', status text not null default 'pending', expires_at text not null, last_polled_at text not null default '', foreign key (client_id) references clients(id) );`
 
 	for i := 1; i <= 5; i++ {
 		tx := db.MustBegin()
@@ -5,7 +5,7 @@ import (
 	"net/url"
 
 	"github.com/spf13/viper"
-	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
 )
 
 type EventConfigurationParams struct {
@@ -14,7 +14,6 @@ type EventConfigurationParams struct {
 }
 
 func ConfigureEvents(p EventConfigurationParams) error {
-	var err error
 	if p.ForwardAddress == "" && p.Secret == "" {
 		return fmt.Errorf("you must provide at least one of --secret or --forward-address")
 	}
@@ -34,12 +33,7 @@ func ConfigureEvents(p EventConfigurationParams) error {
 		viper.Set("eventSecret", p.Secret)
 	}
 
-	configPath, err := util.GetConfigPath()
-	if err != nil {
-		return err
-	}
-
-	if err := viper.WriteConfigAs(configPath); err != nil {
+	if err := secrets.WriteConfig(); err != nil {
 		return fmt.Errorf("failed to write configuration: %v", err.Error())
 	}
 
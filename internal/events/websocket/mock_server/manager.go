@@ -71,7 +71,7 @@ func StartWebsocketServer(enableDebug bool, ip string, port int, enableSSL bool,
 	serverManager.primaryServer = initialServer.ServerId
 
 	// Allow exit with Ctrl + C
-	stop := make(chan os.Signal)
+	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 
 	m := http.NewServeMux()
@@ -155,6 +155,7 @@ func StartWebsocketServer(enableDebug bool, ip string, port int, enableSSL bool,
 	rpc.RegisterHandler("EventSubWebSocketCloseClient", RPCCloseHandler)
 	rpc.RegisterHandler("EventSubWebSocketSubscription", RPCSubscriptionHandler)
 	rpc.RegisterHandler("EventSubWebSocketKeepalive", RPCKeepaliveHandler)
+	rpc.RegisterHandler("EventSubWebSocketAuthorizationRevoke", RPCAuthorizationRevokeHandler)
 	rpc.StartBackgroundServer()
 
 	// TODO: Interactive shell maybe?
@@ -139,6 +139,30 @@ func RPCFireEventSubHandler(args rpc.RPCArgs) rpc.RPCResponse {
 	}
 }
 
+// POST /revoke on the mock auth server
+func RPCAuthorizationRevokeHandler(args rpc.RPCArgs) rpc.RPCResponse {
+	server, ok := serverManager.serverList.Get(serverManager.primaryServer)
+	if !ok {
+		log.Printf("Error on RPC call (EventSubWebSocketAuthorizationRevoke): Primary server not in server list.")
+		return rpc.RPCResponse{
+			ResponseCode: COMMAND_RESPONSE_FAILED_ON_SERVER,
+			DetailedInfo: "Primary server not in server list.",
+		}
+	}
+
+	success, failMsg := server.HandleAuthorizationRevoke(args.Variables["ClientID"], args.Variables["UserID"], args.Variables["UserLogin"])
+	if !success {
+		return rpc.RPCResponse{
+			ResponseCode: COMMAND_RESPONSE_FAILED_ON_SERVER,
+			DetailedInfo: failMsg,
+		}
+	}
+
+	return rpc.RPCResponse{
+		ResponseCode: COMMAND_RESPONSE_SUCCESS,
+	}
+}
+
 // $ twitch event websocket close
 func RPCCloseHandler(args rpc.RPCArgs) rpc.RPCResponse {
 	closeCode, err := strconv.Atoi(args.Variables["CloseReason"])
@@ -518,6 +518,111 @@ func (ws *WebSocketServer) HandleRPCEventSubForwarding(eventsubBody string, clie
 	return true, ""
 }
 
+// Revokes subscriptions belonging to the client ID that reference the user, then notifies connected clients with user.authorization.revoke
+func (ws *WebSocketServer) HandleAuthorizationRevoke(clientID string, userID string, userLogin string) (bool, string) {
+	ws.muSubscriptions.Lock()
+	for client, clientSubscriptions := range ws.Subscriptions {
+		for i, sub := range clientSubscriptions {
+			if sub.ClientID != clientID || sub.Status != STATUS_ENABLED || !conditionReferencesUser(sub.Conditions, userID) {
+				continue
+			}
+
+			ws.Subscriptions[client][i].Status = STATUS_AUTHORIZATION_REVOKED
+			tNow := util.GetTimestamp()
+			ws.Subscriptions[client][i].DisabledAt = &tNow
+			log.Printf("Subscription ID [%v], belonging to Client ID [%v], has been revoked.", sub.SubscriptionID, clientID)
+		}
+	}
+	ws.muSubscriptions.Unlock()
+
+	eventObj := models.EventsubResponse{
+		Subscription: models.EventsubSubscription{
+			ID:      util.RandomGUID(),
+			Status:  STATUS_ENABLED,
+			Type:    "user.authorization.revoke",
+			Version: "1",
+			Condition: models.EventsubCondition{
+				ClientID: clientID,
+			},
+			Transport: models.EventsubTransport{
+				Method: "websocket",
+			},
+			Cost: 1,
+		},
+		Event: models.AuthorizationRevokeEvent{
+			ClientID:  clientID,
+			UserID:    userID,
+			UserLogin: userLogin,
+			UserName:  userLogin,
+		},
+	}
+
+	didSend := false
+	for _, client := range ws.Clients.All() {
+		createdAt := client.ConnectedAtTimestamp
+
+		// In strict mode, only clients subscribed to user.authorization.revoke for this client ID are notified
+		if ws.StrictMode {
+			found := false
+			ws.muSubscriptions.Lock()
+			for _, sub := range ws.Subscriptions[client.clientName] {
+				if sub.Type == eventObj.Subscription.Type && sub.Conditions.ClientID == clientID && sub.Status == STATUS_ENABLED {
+					found = true
+					eventObj.Subscription.ID = sub.SubscriptionID
+					createdAt = sub.CreatedAt
+				}
+			}
+			ws.muSubscriptions.Unlock()
+
+			if !found {
+				continue
+			}
+		}
+
+		eventObj.Subscription.Transport.SessionID = fmt.Sprintf("%v_%v", ws.ServerId, client.clientName)
+		eventObj.Subscription.CreatedAt = createdAt
+
+		notificationMsg, err := json.Marshal(
+			NotificationMessage{
+				Metadata: MessageMetadata{
+					MessageID:           util.RandomGUID(),
+					MessageType:         "notification",
+					MessageTimestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+					SubscriptionType:    eventObj.Subscription.Type,
+					SubscriptionVersion: eventObj.Subscription.Version,
+				},
+				Payload: eventObj,
+			},
+		)
+		if err != nil {
+			msg := fmt.Sprintf("Error building JSON for client [%v]: %v", client.clientName, err.Error())
+			log.Println(msg)
+			return false, msg
+		}
+
+		client.SendMessage(websocket.TextMessage, notificationMsg)
+		log.Printf("Sent [%v / %v] to client [%v]", eventObj.Subscription.Type, eventObj.Subscription.Version, client.clientName)
+
+		didSend = true
+	}
+
+	if !didSend {
+		msg := fmt.Sprintf("No clients are subscribed to [%v / %v]", eventObj.Subscription.Type, eventObj.Subscription.Version)
+		log.Println(msg)
+		return false, msg
+	}
+
+	return true, ""
+}
+
+func conditionReferencesUser(c models.EventsubCondition, userID string) bool {
+	return c.BroadcasterUserID == userID ||
+		c.ToBroadcasterUserID == userID ||
+		c.FromBroadcasterUserID == userID ||
+		c.UserID == userID ||
+		c.ModeratorUserID == userID
+}
+
 func (ws *WebSocketServer) handleClientConnectionClose(client *Client, closeReason *CloseMessage) {
 	// Prevent further looping
 	client.mustSubscribeTimer.Stop()
@@ -0,0 +1,214 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	RoleBroadcaster = "broadcaster"
+	RoleModerator   = "moderator"
+	RoleViewer      = "viewer"
+	RoleExternal    = "external"
+)
+
+var ValidRoles = []string{RoleBroadcaster, RoleModerator, RoleViewer, RoleExternal}
+
+type PubSubPerms struct {
+	Listen []string `json:"listen,omitempty"`
+	Send   []string `json:"send,omitempty"`
+}
+
+// ExtensionClaims is the payload of an Extension JWT, as documented at https://dev.twitch.tv/docs/extensions/reference/#jwt-schema
+type ExtensionClaims struct {
+	Expiration   int64       `json:"exp"`
+	OpaqueUserID string      `json:"opaque_user_id,omitempty"`
+	UserID       string      `json:"user_id,omitempty"`
+	ChannelID    string      `json:"channel_id,omitempty"`
+	Role         string      `json:"role"`
+	IsUnlinked   bool        `json:"is_unlinked,omitempty"`
+	PubSubPerms  PubSubPerms `json:"pubsub_perms"`
+}
+
+type SignParameters struct {
+	Secret       string
+	Role         string
+	ChannelID    string
+	UserID       string
+	OpaqueUserID string
+	ExpiresIn    time.Duration
+	Listen       []string
+	Send         []string
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+}
+
+// NewClaims builds the claims for the given role, filling in the defaults Twitch uses for the opaque user ID and PubSub permissions when they aren't provided.
+func NewClaims(p SignParameters) (ExtensionClaims, error) {
+	if !IsValidRole(p.Role) {
+		return ExtensionClaims{}, fmt.Errorf("Invalid role %v. Valid roles are: %v", p.Role, strings.Join(ValidRoles, ", "))
+	}
+
+	if p.ExpiresIn <= 0 {
+		p.ExpiresIn = 3 * time.Minute
+	}
+
+	c := ExtensionClaims{
+		Expiration: util.GetTimestamp().Add(p.ExpiresIn).Unix(),
+		Role:       p.Role,
+		ChannelID:  p.ChannelID,
+		UserID:     p.UserID,
+		PubSubPerms: PubSubPerms{
+			Listen: p.Listen,
+			Send:   p.Send,
+		},
+	}
+
+	if p.Role == RoleExternal {
+		if c.PubSubPerms.Send == nil {
+			c.PubSubPerms.Send = []string{"broadcast", "global"}
+		}
+		return c, nil
+	}
+
+	c.OpaqueUserID = p.OpaqueUserID
+	if c.OpaqueUserID == "" {
+		if p.UserID != "" {
+			c.OpaqueUserID = "U" + p.UserID
+		} else {
+			// logged out viewers get an anonymous opaque ID that isn't linked to a Twitch user
+			c.OpaqueUserID = "A" + util.RandomClientID()[:20]
+			c.IsUnlinked = true
+		}
+	}
+
+	if c.PubSubPerms.Listen == nil {
+		c.PubSubPerms.Listen = []string{"broadcast", "whisper-" + c.OpaqueUserID, "global"}
+	}
+	if c.PubSubPerms.Send == nil && p.Role == RoleBroadcaster {
+		c.PubSubPerms.Send = []string{"broadcast", "whisper-*"}
+	}
+
+	return c, nil
+}
+
+// Sign creates an HS256 signed JWT using the base64 encoded extension secret.
+func Sign(claims ExtensionClaims, secret string) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(jwtHeader{Algorithm: "HS256", Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signature(unsigned, key), nil
+}
+
+// SignWithParameters is a convenience wrapper around NewClaims and Sign.
+func SignWithParameters(p SignParameters) (string, ExtensionClaims, error) {
+	c, err := NewClaims(p)
+	if err != nil {
+		return "", c, err
+	}
+
+	token, err := Sign(c, p.Secret)
+	return token, c, err
+}
+
+// Verify checks the signature and expiration of the token and returns the decoded claims.
+func Verify(token string, secret string) (ExtensionClaims, error) {
+	var c ExtensionClaims
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return c, err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return c, errors.New("Malformed JWT: expected three segments")
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return c, fmt.Errorf("Malformed JWT header: %v", err)
+	}
+	var h jwtHeader
+	if err := json.Unmarshal(headerBytes, &h); err != nil {
+		return c, fmt.Errorf("Malformed JWT header: %v", err)
+	}
+	if h.Algorithm != "HS256" {
+		return c, fmt.Errorf("Unsupported signing algorithm %v; Extension JWTs must use HS256", h.Algorithm)
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return c, fmt.Errorf("Malformed JWT payload: %v", err)
+	}
+	if err := json.Unmarshal(payloadBytes, &c); err != nil {
+		return c, fmt.Errorf("Malformed JWT payload: %v", err)
+	}
+
+	expected := signature(parts[0]+"."+parts[1], key)
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return c, errors.New("Invalid signature")
+	}
+
+	if c.Expiration != 0 && util.GetTimestamp().Unix() > c.Expiration {
+		return c, fmt.Errorf("Token expired at %v", time.Unix(c.Expiration, 0).UTC().Format(time.RFC3339))
+	}
+
+	if !IsValidRole(c.Role) {
+		return c, fmt.Errorf("Invalid role %v", c.Role)
+	}
+
+	return c, nil
+}
+
+func IsValidRole(role string) bool {
+	for _, r := range ValidRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("Extension secret is required")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("Extension secret must be base64 encoded: %v", err)
+	}
+	return key, nil
+}
+
+func signature(unsigned string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
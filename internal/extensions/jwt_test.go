@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package extensions
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var secret = base64.StdEncoding.EncodeToString([]byte("extension-secret"))
+
+func TestSignAndVerify(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	token, claims, err := SignWithParameters(SignParameters{
+		Secret:    secret,
+		Role:      RoleViewer,
+		ChannelID: "1234",
+		UserID:    "5678",
+	})
+	a.Nil(err)
+	a.Equal("U5678", claims.OpaqueUserID)
+	a.Contains(claims.PubSubPerms.Listen, "whisper-U5678")
+
+	c, err := Verify(token, secret)
+	a.Nil(err)
+	a.Equal(RoleViewer, c.Role)
+	a.Equal("1234", c.ChannelID)
+	a.Equal("5678", c.UserID)
+
+	// wrong secret
+	_, err = Verify(token, base64.StdEncoding.EncodeToString([]byte("potato")))
+	a.NotNil(err)
+
+	// non-base64 secret
+	_, err = Verify(token, "not base64!")
+	a.NotNil(err)
+
+	// malformed
+	_, err = Verify("abc.def", secret)
+	a.NotNil(err)
+}
+
+func TestNewClaims(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	_, err := NewClaims(SignParameters{Role: "potato"})
+	a.NotNil(err)
+
+	c, err := NewClaims(SignParameters{Role: RoleExternal, UserID: "1"})
+	a.Nil(err)
+	a.Empty(c.OpaqueUserID)
+	a.Equal([]string{"broadcast", "global"}, c.PubSubPerms.Send)
+
+	c, err = NewClaims(SignParameters{Role: RoleViewer})
+	a.Nil(err)
+	a.True(c.IsUnlinked)
+	a.Equal("A", c.OpaqueUserID[:1])
+
+	c, err = NewClaims(SignParameters{Role: RoleBroadcaster, UserID: "1", ChannelID: "1"})
+	a.Nil(err)
+	a.NotEmpty(c.PubSubPerms.Send)
+}
+
+func TestVerifyExpired(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	c, err := NewClaims(SignParameters{Role: RoleExternal, UserID: "1"})
+	a.Nil(err)
+	c.Expiration = time.Now().Add(-1 * time.Minute).Unix()
+
+	token, err := Sign(c, secret)
+	a.Nil(err)
+
+	_, err = Verify(token, secret)
+	a.NotNil(err)
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/secrets"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -30,6 +31,7 @@ type LoginParameters struct {
 	URL          string
 	RedirectURL  string
 	AuthorizeURL string
+	DeviceURL    string
 }
 
 type RefreshParameters struct {
@@ -74,19 +76,31 @@ type DeviceCodeFlowInitResponse struct {
 	VerificationUri string `json:"verification_uri"`
 }
 
-const ClientCredentialsURL = "https://id.twitch.tv/oauth2/token?grant_type=client_credentials"
-const UserCredentialsURL = "https://id.twitch.tv/oauth2/token?grant_type=authorization_code"
+// IDBaseURL is the base of all production OAuth URLs below; see WithBaseURL to point them at another server, such as the mock API's /auth namespace
+const IDBaseURL = "https://id.twitch.tv/oauth2"
 
-const UserAuthorizeURL = "https://id.twitch.tv/oauth2/authorize?response_type=code"
+const ClientCredentialsURL = IDBaseURL + "/token?grant_type=client_credentials"
+const UserCredentialsURL = IDBaseURL + "/token?grant_type=authorization_code"
 
-const RefreshTokenURL = "https://id.twitch.tv/oauth2/token?grant_type=refresh_token"
-const RevokeTokenURL = "https://id.twitch.tv/oauth2/revoke"
-const ValidateTokenURL = "https://id.twitch.tv/oauth2/validate"
+const UserAuthorizeURL = IDBaseURL + "/authorize?response_type=code"
 
-const DeviceCodeFlowUrl = "https://id.twitch.tv/oauth2/device"
-const DeviceCodeFlowTokenURL = "https://id.twitch.tv/oauth2/token"
+const RefreshTokenURL = IDBaseURL + "/token?grant_type=refresh_token"
+const RevokeTokenURL = IDBaseURL + "/revoke"
+const ValidateTokenURL = IDBaseURL + "/validate"
+
+const DeviceCodeFlowUrl = IDBaseURL + "/device"
+const DeviceCodeFlowTokenURL = IDBaseURL + "/token"
 const DeviceCodeFlowGrantType = "urn:ietf:params:oauth:grant-type:device_code"
 
+// WithBaseURL swaps the production base of one of the OAuth URL constants for baseURL, e.g. http://localhost:8080/auth.
+// An empty baseURL returns the URL unchanged.
+func WithBaseURL(u string, baseURL string) string {
+	if baseURL == "" {
+		return u
+	}
+	return strings.TrimSuffix(baseURL, "/") + strings.TrimPrefix(u, IDBaseURL)
+}
+
 // Sends `https://id.twitch.tv/oauth2/token?grant_type=client_credentials`.
 // Generates a new App Access Token. Stores new token information in the CLI's config.
 func ClientCredentialsLogin(p LoginParameters) (LoginResponse, error) {
@@ -108,7 +122,7 @@ func ClientCredentialsLogin(p LoginParameters) (LoginResponse, error) {
 		return LoginResponse{}, errors.New("API responded with an error while revoking token: " + string(resp.Body))
 	}
 
-	r, err := handleLoginResponse(resp.Body, true)
+	r, err := handleLoginResponse(resp.Body, true, "")
 	if err != nil {
 		return LoginResponse{}, fmt.Errorf("Error processing login response: %v", err.Error())
 	}
@@ -182,7 +196,7 @@ func UserCredentialsLogin_AuthorizationCodeFlow(p LoginParameters, webserverIP s
 		)
 	}
 
-	r, err := handleLoginResponse(resp.Body, true)
+	r, err := handleLoginResponse(resp.Body, true, "")
 	if err != nil {
 		return LoginResponse{}, fmt.Errorf("Error handling login: %v", err.Error())
 	}
@@ -193,8 +207,17 @@ func UserCredentialsLogin_AuthorizationCodeFlow(p LoginParameters, webserverIP s
 // Uses Device Code Flow: https://dev.twitch.tv/docs/authentication/getting-tokens-oauth/#device-code-grant-flow
 // Generates a new User Access Token, requiring the use of a web browser from any device. Stores new token information in the CLI's config.
 func UserCredentialsLogin_DeviceCodeFlow(p LoginParameters) (LoginResponse, error) {
+	deviceURL := DeviceCodeFlowUrl
+	if p.DeviceURL != "" {
+		deviceURL = p.DeviceURL
+	}
+	tokenURL := DeviceCodeFlowTokenURL
+	if p.URL != "" {
+		tokenURL = p.URL
+	}
+
 	// Initiate DCF flow
-	deviceResp, err := dcfInitiateRequest(DeviceCodeFlowUrl, p.ClientID, p.Scopes)
+	deviceResp, err := dcfInitiateRequest(deviceURL, p.ClientID, p.Scopes)
 	if err != nil {
 		return LoginResponse{}, fmt.Errorf("Error initiating Device Code Flow: %v", err.Error())
 	}
@@ -222,13 +245,13 @@ func UserCredentialsLogin_DeviceCodeFlow(p LoginParameters) (LoginResponse, erro
 		time.Sleep(time.Second * time.Duration(deviceObj.Interval))
 
 		// Check for token
-		tokenResp, err = dcfTokenRequest(DeviceCodeFlowTokenURL, p.ClientID, p.Scopes, deviceObj.DeviceCode, DeviceCodeFlowGrantType)
+		tokenResp, err = dcfTokenRequest(tokenURL, p.ClientID, p.Scopes, deviceObj.DeviceCode, DeviceCodeFlowGrantType)
 		if err != nil {
 			return LoginResponse{}, fmt.Errorf("Error getting token via Device Code Flow: %v", err)
 		}
 
 		if tokenResp.StatusCode == 200 {
-			r, err := handleLoginResponse(tokenResp.Body, true)
+			r, err := handleLoginResponse(tokenResp.Body, true, "")
 			if err != nil {
 				return LoginResponse{}, fmt.Errorf("Error handling login: %v", err.Error())
 			}
@@ -285,7 +308,8 @@ func RefreshUserToken(p RefreshParameters, shouldStoreInConfig bool) (LoginRespo
 		return LoginResponse{}, fmt.Errorf("Error with client while refreshing: [%v - `%v`]", resp.StatusCode, strings.TrimSpace(string(resp.Body)))
 	}
 
-	r, err := handleLoginResponse(resp.Body, shouldStoreInConfig)
+	// the refreshed token belongs to the same user, so the user ID looked up for the previous token is kept
+	r, err := handleLoginResponse(resp.Body, shouldStoreInConfig, viper.GetString("tokenUserID"))
 	if err != nil {
 		return LoginResponse{}, fmt.Errorf("Error handling login: %v", err.Error())
 	}
@@ -320,7 +344,8 @@ func ValidateCredentials(p LoginParameters) (ValidateResponse, error) {
 	return r, nil
 }
 
-func handleLoginResponse(body []byte, shouldStoreInConfig bool) (LoginResponse, error) {
+// handleLoginResponse parses a token response, storing it in the config with userID, the ID of the token's user if it's already known
+func handleLoginResponse(body []byte, shouldStoreInConfig bool, userID string) (LoginResponse, error) {
 	var r AuthorizationResponse
 	if err := json.Unmarshal(body, &r); err != nil {
 		return LoginResponse{}, err
@@ -328,7 +353,7 @@ func handleLoginResponse(body []byte, shouldStoreInConfig bool) (LoginResponse,
 	expiresAt := util.GetTimestamp().Add(time.Duration(int64(time.Second) * int64(r.ExpiresIn)))
 
 	if shouldStoreInConfig {
-		storeInConfig(r.AccessToken, r.RefreshToken, r.Scope, expiresAt)
+		storeInConfig(r.AccessToken, r.RefreshToken, r.Scope, expiresAt, userID)
 	}
 
 	return LoginResponse{
@@ -417,17 +442,14 @@ func userAuthServer(ip string, port string, onSuccessfulListenCallback func()) (
 	return &userAuthResponse, userAuthResponse.Error
 }
 
-func storeInConfig(token string, refresh string, scopes []string, expiresAt time.Time) {
+func storeInConfig(token string, refresh string, scopes []string, expiresAt time.Time, userID string) {
 	viper.Set("accessToken", token)
 	viper.Set("refreshToken", refresh)
 	viper.Set("tokenScopes", scopes)
 	viper.Set("tokenExpiration", expiresAt.Format(time.RFC3339Nano))
+	viper.Set("tokenUserID", userID)
 
-	err := viper.WriteConfig()
-	if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-		err = viper.SafeWriteConfig()
-	}
-
+	err := secrets.WriteConfig()
 	if err != nil {
 		log.Fatalf("Error writing configuration: %s", err)
 	}
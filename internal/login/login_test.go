@@ -97,12 +97,13 @@ func TestStoreInConfig(t *testing.T) {
 	a := test_setup.SetupTestEnv(t)
 
 	r := response.Response
-	storeInConfig(r.AccessToken, r.RefreshToken, r.Scope, response.ExpiresAt)
+	storeInConfig(r.AccessToken, r.RefreshToken, r.Scope, response.ExpiresAt, "141981764")
 
 	a.Equal(r.AccessToken, viper.Get("accesstoken"), "Invalid token in config.")
 	a.Equal(r.RefreshToken, viper.Get("refreshtoken"), "Invalid refresh token in config.")
 	a.Equal(r.Scope, viper.Get("tokenscopes"), "Invalid scopes in config.")
 	a.Equal(response.ExpiresAt.Format(time.RFC3339Nano), viper.GetString("tokenexpiration"), "Invalid expiration in config.")
+	a.Equal("141981764", viper.GetString("tokenuserid"), "Invalid user ID in config.")
 }
 
 func TestRefreshUserToken(t *testing.T) {
@@ -159,3 +160,11 @@ func TestUserAuthServer(t *testing.T) {
 	a.Equal(state, ur.State, "State mismatch")
 	a.Equal(code, ur.Code, "Code mismatch")
 }
+
+func TestWithBaseURL(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal(UserAuthorizeURL, WithBaseURL(UserAuthorizeURL, ""))
+	a.Equal("http://localhost:8080/auth/authorize?response_type=code", WithBaseURL(UserAuthorizeURL, "http://localhost:8080/auth/"))
+	a.Equal("http://localhost:8080/auth/validate", WithBaseURL(ValidateTokenURL, "http://localhost:8080/auth"))
+}
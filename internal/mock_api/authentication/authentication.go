@@ -14,6 +14,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 type UserAuthentication struct {
@@ -93,6 +94,17 @@ func AuthenticationMiddleware(next mock_api.MockEndpoint) http.Handler {
 			ClientID: auth.ClientID,
 		}
 
+		// check the token type before scopes, as app access tokens can't have the user scopes these endpoints require
+		if e, ok := next.(mock_api.TokenTypeEndpoint); ok && !authContext.IsAcceptedTokenType(e.GetAcceptedTokenTypes(r.Method)) {
+			message := "Missing User OAUTH Token"
+			if authContext.UserID != "" {
+				message = "App access token is required"
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write(mock_errors.GetErrorBytes(http.StatusUnauthorized, errors.New("Unauthorized"), message))
+			return
+		}
+
 		if authContext.HasOneOfRequiredScope(next.GetRequiredScopes(r.Method)) == false {
 			w.WriteHeader(http.StatusUnauthorized)
 			w.Write(mock_errors.GetErrorBytes(http.StatusUnauthorized, errors.New("Unauthorized"), fmt.Sprintf("Missing required scope %v", strings.Join(next.GetRequiredScopes(r.Method), " or "))))
@@ -130,6 +142,24 @@ func (u UserAuthentication) HasOneOfRequiredScope(scopes []string) bool {
 	return false
 }
 
+// IsAcceptedTokenType returns whether the token is one of the accepted token types; no accepted token types means any is accepted
+func (u UserAuthentication) IsAcceptedTokenType(tokenTypes []string) bool {
+	if len(tokenTypes) == 0 {
+		return true
+	}
+
+	tokenType := scopes.AppAccessToken
+	if u.UserID != "" {
+		tokenType = scopes.UserAccessToken
+	}
+	for _, t := range tokenTypes {
+		if t == tokenType {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *UserAuthentication) MatchesBroadcasterIDParam(r *http.Request) bool {
 	return u.MatchesSpecifiedIDParam(r, "broadcaster_id")
 }
@@ -5,6 +5,7 @@ package authentication
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 	"github.com/twitchdev/twitch-cli/test_setup"
 )
@@ -89,6 +91,58 @@ func TestAuthenticationMiddleware(t *testing.T) {
 	a.Equal(401, resp.StatusCode)
 }
 
+func TestTokenTypes(t *testing.T) {
+	a = test_setup.SetupTestEnv(t)
+	ts := httptest.NewServer(baseMiddleware(AuthenticationMiddleware(testUserTokenEndpoint{})))
+
+	// user token
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+testUserTokenEndpoint{}.Path(), nil)
+	req.Header.Set("Client-ID", ac.ID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	// user token on an app token only method
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+testUserTokenEndpoint{}.Path(), nil)
+	req.Header.Set("Client-ID", ac.ID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	// app token
+	db, err := database.NewConnection(false)
+	a.Nil(err)
+	auth, err := db.NewQuery(nil, 100).CreateAuthorization(database.Authorization{ClientID: ac.ID, ExpiresAt: util.GetTimestamp().Add(time.Hour).Format(time.RFC3339)})
+	a.Nil(err)
+	db.DB.Close()
+
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+testUserTokenEndpoint{}.Path(), nil)
+	req.Header.Set("Client-ID", ac.ID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", auth.Token))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	a.Contains(string(body), "Missing User OAUTH Token")
+
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+testUserTokenEndpoint{}.Path(), nil)
+	req.Header.Set("Client-ID", ac.ID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", auth.Token))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	// methods without accepted token types accept either
+	req, _ = http.NewRequest(http.MethodPut, ts.URL+testUserTokenEndpoint{}.Path(), nil)
+	req.Header.Set("Client-ID", ac.ID)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", auth.Token))
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+}
+
 func baseMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := context.Background()
@@ -136,3 +190,15 @@ func (e testEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	a.NotNil(userCtx)
 	w.WriteHeader(200)
 }
+
+type testUserTokenEndpoint struct{ testEndpoint }
+
+func (e testUserTokenEndpoint) GetAcceptedTokenTypes(method string) []string {
+	switch method {
+	case http.MethodGet:
+		return []string{scopes.UserAccessToken}
+	case http.MethodPost:
+		return []string{scopes.AppAccessToken}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package analytics
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+	"github.com/twitchdev/twitch-cli/test_setup/test_server"
+)
+
+var extension database.AuthenticationClient
+var category database.Category
+
+func TestMain(m *testing.M) {
+	test_setup.SetupTestEnv(&testing.T{})
+
+	db, err := database.NewConnection(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	extension, err = db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{
+		ID:          util.RandomClientID(),
+		Name:        "Test Extension",
+		IsExtension: true,
+	}, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	category = database.Category{ID: util.RandomUserID(), Name: "Test Category", IGDB: "1"}
+	err = db.NewQuery(nil, 100).InsertCategory(category, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db.DB.Close()
+
+	os.Exit(m.Run())
+}
+
+func TestExtensionAnalytics(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(ExtensionAnalytics{})
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+ExtensionAnalytics{}.Path(), nil)
+	q := req.URL.Query()
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	q.Set("extension_id", extension.ID)
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var r struct {
+		Data []ExtensionAnalyticsReport `json:"data"`
+	}
+	a.Nil(json.Unmarshal(body, &r))
+	a.Len(r.Data, 1)
+	a.Equal(extension.ID, r.Data[0].ExtensionID)
+	a.True(strings.Contains(r.Data[0].URL, "/mock-files/analytics/extensions/"+extension.ID+"/overview_v2.csv"))
+
+	// unknown extension
+	q.Set("extension_id", "potato")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// bad type
+	q.Set("extension_id", extension.ID)
+	q.Set("type", "overview_v1")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// started_at without ended_at
+	q.Del("type")
+	q.Set("started_at", "2023-01-01T00:00:00Z")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// ended_at before started_at
+	q.Set("ended_at", "2022-12-01T00:00:00Z")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// before data is available
+	q.Set("started_at", "2017-01-01T00:00:00Z")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	q.Set("started_at", "2023-01-01T12:34:56Z")
+	q.Set("ended_at", "2023-01-31T00:00:00Z")
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ = io.ReadAll(resp.Body)
+	a.Nil(json.Unmarshal(body, &r))
+	a.Equal("2023-01-01T00:00:00Z", r.Data[0].DateRange.StartedAt)
+	a.Equal("2023-01-31T00:00:00Z", r.Data[0].DateRange.EndedAt)
+}
+
+func TestGameAnalytics(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+	ts := test_server.SetupTestServer(GameAnalytics{})
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+GameAnalytics{}.Path(), nil)
+	q := req.URL.Query()
+	q.Set("first", "1")
+	req.URL.RawQuery = q.Encode()
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var r models.APIResponse
+	a.Nil(json.Unmarshal(body, &r))
+	a.NotNil(r.Pagination)
+
+	q.Del("first")
+	q.Set("game_id", category.ID)
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	// games only retain a year of data
+	q.Set("started_at", util.GetTimestamp().AddDate(-2, 0, 0).Format(time.RFC3339))
+	q.Set("ended_at", util.GetTimestamp().AddDate(0, 0, -2).Format(time.RFC3339))
+	req.URL.RawQuery = q.Encode()
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package analytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_files"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+)
+
+var extensionAnalyticsMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var extensionAnalyticsScopesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.AnalyticsReadExtensions},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+var extensionAnalyticsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
+// Extension analytics are available from January 31, 2018 onwards
+var extensionAnalyticsEarliestDate = time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC)
+
+type ExtensionAnalytics struct{}
+
+type ExtensionAnalyticsReport struct {
+	ExtensionID string    `json:"extension_id"`
+	URL         string    `json:"URL"`
+	Type        string    `json:"type"`
+	DateRange   DateRange `json:"date_range"`
+}
+
+func (e ExtensionAnalytics) Path() string { return "/analytics/extensions" }
+
+func (e ExtensionAnalytics) GetRequiredScopes(method string) []string {
+	return extensionAnalyticsScopesByMethod[method]
+}
+
+func (e ExtensionAnalytics) GetAcceptedTokenTypes(method string) []string {
+	return extensionAnalyticsTokenTypesByMethod[method]
+}
+
+func (e ExtensionAnalytics) ValidMethod(method string) bool {
+	return extensionAnalyticsMethodsSupported[method]
+}
+
+func (e ExtensionAnalytics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	switch r.Method {
+	case http.MethodGet:
+		getExtensionAnalytics(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getExtensionAnalytics(w http.ResponseWriter, r *http.Request) {
+	reportType, startedAt, endedAt, err := getReportRange(r, extensionAnalyticsEarliestDate)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, err.Error())
+		return
+	}
+
+	extensionID := r.URL.Query().Get("extension_id")
+
+	// pagination is ignored when requesting a specific extension
+	req := r
+	if extensionID != "" {
+		req = nil
+	}
+	dbr, err := db.NewQuery(req, 100).GetAuthenticationClient(database.AuthenticationClient{ID: extensionID, IsExtension: true})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := dbr.Data.([]database.AuthenticationClient)
+
+	if extensionID != "" && len(clients) == 0 {
+		mock_errors.WriteBadRequest(w, "The parameter \"extension_id\" was malformed: the value must be the ID of an extension you own")
+		return
+	}
+
+	reports := []ExtensionAnalyticsReport{}
+	for _, c := range clients {
+		reports = append(reports, ExtensionAnalyticsReport{
+			ExtensionID: c.ID,
+			URL:         mock_files.AnalyticsReportURL(r, mock_files.AnalyticsExtensions, c.ID, reportType, startedAt, endedAt),
+			Type:        reportType,
+			DateRange: DateRange{
+				StartedAt: startedAt.Format(time.RFC3339),
+				EndedAt:   endedAt.Format(time.RFC3339),
+			},
+		})
+	}
+
+	apiResponse := models.APIResponse{
+		Data: reports,
+	}
+	if extensionID == "" && len(reports) == dbr.Limit {
+		apiResponse.Pagination = &models.APIPagination{
+			Cursor: dbr.Cursor,
+		}
+	}
+
+	bytes, _ := json.Marshal(apiResponse)
+	w.Write(bytes)
+}
@@ -0,0 +1,126 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package analytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/mock_files"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var gameAnalyticsMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var gameAnalyticsScopesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.AnalyticsReadGames},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+var gameAnalyticsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
+type GameAnalytics struct{}
+
+type GameAnalyticsReport struct {
+	GameID    string    `json:"game_id"`
+	URL       string    `json:"URL"`
+	Type      string    `json:"type"`
+	DateRange DateRange `json:"date_range"`
+}
+
+func (e GameAnalytics) Path() string { return "/analytics/games" }
+
+func (e GameAnalytics) GetRequiredScopes(method string) []string {
+	return gameAnalyticsScopesByMethod[method]
+}
+
+func (e GameAnalytics) GetAcceptedTokenTypes(method string) []string {
+	return gameAnalyticsTokenTypesByMethod[method]
+}
+
+func (e GameAnalytics) ValidMethod(method string) bool {
+	return gameAnalyticsMethodsSupported[method]
+}
+
+func (e GameAnalytics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	switch r.Method {
+	case http.MethodGet:
+		getGameAnalytics(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getGameAnalytics(w http.ResponseWriter, r *http.Request) {
+	// game analytics are only retained for a year
+	now := util.GetTimestamp()
+	earliest := time.Date(now.Year()-1, now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	reportType, startedAt, endedAt, err := getReportRange(r, earliest)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, err.Error())
+		return
+	}
+
+	gameID := r.URL.Query().Get("game_id")
+
+	// pagination is ignored when requesting a specific game
+	req := r
+	if gameID != "" {
+		req = nil
+	}
+	dbr, err := db.NewQuery(req, 100).GetCategories(database.Category{ID: gameID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	games := dbr.Data.([]database.Category)
+
+	if gameID != "" && len(games) == 0 {
+		mock_errors.WriteBadRequest(w, "The parameter \"game_id\" was malformed: the value must be the ID of a game you own")
+		return
+	}
+
+	reports := []GameAnalyticsReport{}
+	for _, g := range games {
+		reports = append(reports, GameAnalyticsReport{
+			GameID: g.ID,
+			URL:    mock_files.AnalyticsReportURL(r, mock_files.AnalyticsGames, g.ID, reportType, startedAt, endedAt),
+			Type:   reportType,
+			DateRange: DateRange{
+				StartedAt: startedAt.Format(time.RFC3339),
+				EndedAt:   endedAt.Format(time.RFC3339),
+			},
+		})
+	}
+
+	apiResponse := models.APIResponse{
+		Data: reports,
+	}
+	if gameID == "" && len(reports) == dbr.Limit {
+		apiResponse.Pagination = &models.APIPagination{
+			Cursor: dbr.Cursor,
+		}
+	}
+
+	bytes, _ := json.Marshal(apiResponse)
+	w.Write(bytes)
+}
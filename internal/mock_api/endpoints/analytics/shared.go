@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package analytics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+var db database.CLIDatabase
+
+const reportTypeOverviewV2 = "overview_v2"
+
+type DateRange struct {
+	StartedAt string `json:"started_at"`
+	EndedAt   string `json:"ended_at"`
+}
+
+// getReportRange validates the type, started_at, and ended_at parameters shared by the analytics endpoints.
+// Returned dates are truncated to midnight UTC; when no range is provided the last year of data is used.
+func getReportRange(r *http.Request, earliest time.Time) (string, time.Time, time.Time, error) {
+	q := r.URL.Query()
+	reportType := q.Get("type")
+	startedAt := q.Get("started_at")
+	endedAt := q.Get("ended_at")
+
+	if reportType == "" {
+		reportType = reportTypeOverviewV2
+	}
+	if reportType != reportTypeOverviewV2 {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"type\" was malformed: the value must be overview_v2")
+	}
+
+	now := util.GetTimestamp()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if startedAt == "" && endedAt == "" {
+		start := today.AddDate(-1, 0, 0)
+		if start.Before(earliest) {
+			start = earliest
+		}
+		return reportType, start, today.AddDate(0, 0, -1), nil
+	}
+
+	if startedAt == "" || endedAt == "" {
+		return "", time.Time{}, time.Time{}, errors.New("The parameters started_at and ended_at must be specified together")
+	}
+
+	sa, err := time.Parse(time.RFC3339, startedAt)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"started_at\" was malformed: the value must be in RFC3339 format")
+	}
+	ea, err := time.Parse(time.RFC3339, endedAt)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"ended_at\" was malformed: the value must be in RFC3339 format")
+	}
+
+	sa = time.Date(sa.Year(), sa.Month(), sa.Day(), 0, 0, 0, 0, time.UTC)
+	ea = time.Date(ea.Year(), ea.Month(), ea.Day(), 0, 0, 0, 0, time.UTC)
+
+	if sa.Before(earliest) {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"started_at\" must be on or after " + earliest.Format("January 2, 2006"))
+	}
+	if ea.Before(sa) {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"ended_at\" must be after \"started_at\"")
+	}
+	if !ea.Before(today) {
+		return "", time.Time{}, time.Time{}, errors.New("The parameter \"ended_at\" must be before today")
+	}
+
+	return reportType, sa, ea, nil
+}
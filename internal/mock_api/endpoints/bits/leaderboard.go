@@ -11,9 +11,9 @@ import (
 	"time"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
-	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -26,13 +26,17 @@ var leaderboardMethodsSupported = map[string]bool{
 }
 
 var leaderboardScopesByMethod = map[string][]string{
-	http.MethodGet:    {"bits:read"},
+	http.MethodGet:    {scopes.BitsRead},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var leaderboardTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type BitsLeaderboard struct{}
 
 type BitsLeaderboardResponse struct {
@@ -57,6 +61,10 @@ func (e BitsLeaderboard) GetRequiredScopes(method string) []string {
 	return leaderboardScopesByMethod[method]
 }
 
+func (e BitsLeaderboard) GetAcceptedTokenTypes(method string) []string {
+	return leaderboardTokenTypesByMethod[method]
+}
+
 func (e BitsLeaderboard) ValidMethod(method string) bool {
 	return leaderboardMethodsSupported[method]
 }
@@ -78,17 +86,10 @@ func getBitsLeaderboard(w http.ResponseWriter, r *http.Request) {
 	startedAt := r.URL.Query().Get("started_at")
 	userID := r.URL.Query().Get("user_id")
 	count := r.URL.Query().Get("count")
-	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
 	bl := []BitsLeaderboardResponse{}
 	dateRange := models.BitsLeaderboardDateRange{}
 
-	// check for empty auth context (aka app access tokens)
-	if userCtx.UserID == "" {
-		w.Write(mock_errors.GetErrorBytes(http.StatusUnauthorized, errors.New("Unauthorized"), "App access tokens are unable to use /bits/leaderboard"))
-		return
-	}
-
 	// default the period value
 	if period == "" {
 		period = "all"
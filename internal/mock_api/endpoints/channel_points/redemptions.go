@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var redemptionMethodsSupported = map[string]bool{
@@ -22,13 +23,18 @@ var redemptionMethodsSupported = map[string]bool{
 }
 
 var redemptionScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:redemptions", "channel:manage:redemptions"},
+	http.MethodGet:    {scopes.ChannelReadRedemptions, scopes.ChannelManageRedemptions},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"channel:manage:redemptions"},
+	http.MethodPatch:  {scopes.ChannelManageRedemptions},
 	http.MethodPut:    {},
 }
 
+var redemptionTokenTypesByMethod = map[string][]string{
+	http.MethodGet:   {scopes.UserAccessToken},
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 type Redemption struct{}
 
 type PatchRedemptionBody struct {
@@ -41,6 +47,10 @@ func (e Redemption) GetRequiredScopes(method string) []string {
 	return redemptionScopesByMethod[method]
 }
 
+func (e Redemption) GetAcceptedTokenTypes(method string) []string {
+	return redemptionTokenTypesByMethod[method]
+}
+
 func (e Redemption) ValidMethod(method string) bool {
 	return redemptionMethodsSupported[method]
 }
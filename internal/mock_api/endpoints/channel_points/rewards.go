@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -23,13 +24,20 @@ var rewardMethodsSupported = map[string]bool{
 }
 
 var rewardScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:redemptions", "channel:manage:redemptions"},
-	http.MethodPost:   {"channel:manage:redemptions"},
-	http.MethodDelete: {"channel:manage:redemptions"},
-	http.MethodPatch:  {"channel:manage:redemptions"},
+	http.MethodGet:    {scopes.ChannelReadRedemptions, scopes.ChannelManageRedemptions},
+	http.MethodPost:   {scopes.ChannelManageRedemptions},
+	http.MethodDelete: {scopes.ChannelManageRedemptions},
+	http.MethodPatch:  {scopes.ChannelManageRedemptions},
 	http.MethodPut:    {},
 }
 
+var rewardTokenTypesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.UserAccessToken},
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodPatch:  {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type Reward struct{}
 
 type PatchAndPostRewardBody struct {
@@ -55,6 +63,10 @@ func (e Reward) GetRequiredScopes(method string) []string {
 	return rewardScopesByMethod[method]
 }
 
+func (e Reward) GetAcceptedTokenTypes(method string) []string {
+	return rewardTokenTypesByMethod[method]
+}
+
 func (e Reward) ValidMethod(method string) bool {
 	return rewardMethodsSupported[method]
 }
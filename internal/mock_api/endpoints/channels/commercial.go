@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var commercialMethodsSupported = map[string]bool{
@@ -24,12 +25,16 @@ var commercialMethodsSupported = map[string]bool{
 
 var commercialScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"channel:edit:commercial"},
+	http.MethodPost:   {scopes.ChannelEditCommercial},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var commercialTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type CommercialEndpoint struct{}
 
 type CommercialEndpointRequest struct {
@@ -52,6 +57,10 @@ func (e CommercialEndpoint) GetRequiredScopes(method string) []string {
 	return commercialScopesByMethod[method]
 }
 
+func (e CommercialEndpoint) GetAcceptedTokenTypes(method string) []string {
+	return commercialTokenTypesByMethod[method]
+}
+
 func (e CommercialEndpoint) ValidMethod(method string) bool {
 	return commercialMethodsSupported[method]
 }
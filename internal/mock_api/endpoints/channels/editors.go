@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var editorMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var editorMethodsSupported = map[string]bool{
 }
 
 var editorScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:editors"},
+	http.MethodGet:    {scopes.ChannelReadEditors},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var editorTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type Editors struct{}
 
 func (e Editors) Path() string { return "/channels/editors" }
@@ -36,6 +41,10 @@ func (e Editors) GetRequiredScopes(method string) []string {
 	return editorScopesByMethod[method]
 }
 
+func (e Editors) GetAcceptedTokenTypes(method string) []string {
+	return editorTokenTypesByMethod[method]
+}
+
 func (e Editors) ValidMethod(method string) bool {
 	return editorMethodsSupported[method]
 }
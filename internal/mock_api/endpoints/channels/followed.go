@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var followedMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var followedMethodsSupported = map[string]bool{
 }
 
 var followedScopesByMethod = map[string][]string{
-	http.MethodGet:    {"user:read:follows"},
+	http.MethodGet:    {scopes.UserReadFollows},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var followedTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type FollowedEndpoint struct{}
 
 type GetFollowedEndpointResponseData struct {
@@ -43,6 +48,10 @@ func (e FollowedEndpoint) GetRequiredScopes(method string) []string {
 	return followedScopesByMethod[method]
 }
 
+func (e FollowedEndpoint) GetAcceptedTokenTypes(method string) []string {
+	return followedTokenTypesByMethod[method]
+}
+
 func (e FollowedEndpoint) ValidMethod(method string) bool {
 	return followedMethodsSupported[method]
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var followersMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var followersMethodsSupported = map[string]bool{
 }
 
 var followersScopesByMethod = map[string][]string{
-	http.MethodGet:    {"moderator:read:followers"},
+	http.MethodGet:    {scopes.ModeratorReadFollowers},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var followersTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type FollowersEndpoint struct{}
 
 type GetFollowersEndpointResponseData struct {
@@ -43,6 +48,10 @@ func (e FollowersEndpoint) GetRequiredScopes(method string) []string {
 	return followersScopesByMethod[method]
 }
 
+func (e FollowersEndpoint) GetAcceptedTokenTypes(method string) []string {
+	return followersTokenTypesByMethod[method]
+}
+
 func (e FollowersEndpoint) ValidMethod(method string) bool {
 	return followersMethodsSupported[method]
 }
@@ -95,7 +104,7 @@ func getFollowers(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if !userCtx.HasScope("moderator:read:followers") {
+	if !userCtx.HasScope(scopes.ModeratorReadFollowers) {
 		// Doesn't matter if they are broadcaster, moderator, or regular: if they don't have the scope they don't get the data.
 		trustedUser = false
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 type Channel struct {
@@ -42,10 +43,14 @@ var informationScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"channel:manage:broadcast"},
+	http.MethodPatch:  {scopes.ChannelManageBroadcast},
 	http.MethodPut:    {},
 }
 
+var informationTokenTypesByMethod = map[string][]string{
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 type InformationEndpoint struct{}
 
 type PatchInformationEndpointRequest struct {
@@ -69,6 +74,10 @@ func (e InformationEndpoint) GetRequiredScopes(method string) []string {
 	return informationScopesByMethod[method]
 }
 
+func (e InformationEndpoint) GetAcceptedTokenTypes(method string) []string {
+	return informationTokenTypesByMethod[method]
+}
+
 func (e InformationEndpoint) ValidMethod(method string) bool {
 	return informationMethodsSupported[method]
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var vipsMethodsSupported = map[string]bool{
@@ -21,13 +22,19 @@ var vipsMethodsSupported = map[string]bool{
 }
 
 var vipsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:vips", "channel:manage:vips"},
-	http.MethodPost:   {"channel:manage:vips"},
-	http.MethodDelete: {"channel:manage:vips"},
+	http.MethodGet:    {scopes.ChannelReadVips, scopes.ChannelManageVips},
+	http.MethodPost:   {scopes.ChannelManageVips},
+	http.MethodDelete: {scopes.ChannelManageVips},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var vipsTokenTypesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.UserAccessToken},
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type GetVIPsResponseBody struct {
 	UserID    string `json:"user_id"`
 	UserName  string `json:"user_name"`
@@ -42,6 +49,10 @@ func (e Vips) GetRequiredScopes(method string) []string {
 	return vipsScopesByMethod[method]
 }
 
+func (e Vips) GetAcceptedTokenTypes(method string) []string {
+	return vipsTokenTypesByMethod[method]
+}
+
 func (e Vips) ValidMethod(method string) bool {
 	return vipsMethodsSupported[method]
 }
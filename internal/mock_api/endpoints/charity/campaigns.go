@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -24,13 +25,17 @@ var campaignsMethodsSupported = map[string]bool{
 }
 
 var campaignsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:charity"},
+	http.MethodGet:    {scopes.ChannelReadCharity},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var campaignsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type CharityCampaign struct{}
 
 type GetCharityCampaignResponse struct {
@@ -52,6 +57,10 @@ func (e CharityCampaign) GetRequiredScopes(method string) []string {
 	return campaignsScopesByMethod[method]
 }
 
+func (e CharityCampaign) GetAcceptedTokenTypes(method string) []string {
+	return campaignsTokenTypesByMethod[method]
+}
+
 func (e CharityCampaign) ValidMethod(method string) bool {
 	return campaignsMethodsSupported[method]
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -24,13 +25,17 @@ var donationsMethodsSupported = map[string]bool{
 }
 
 var donationsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:charity"},
+	http.MethodGet:    {scopes.ChannelReadCharity},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var donationsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type CharityDonations struct{}
 
 type GetCharityDonationsResponse struct {
@@ -48,6 +53,10 @@ func (e CharityDonations) GetRequiredScopes(method string) []string {
 	return donationsScopesByMethod[method]
 }
 
+func (e CharityDonations) GetAcceptedTokenTypes(method string) []string {
+	return donationsTokenTypesByMethod[method]
+}
+
 func (e CharityDonations) ValidMethod(method string) bool {
 	return donationsMethodsSupported[method]
 }
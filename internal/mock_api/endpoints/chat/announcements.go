@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var announcementsMethodsSupported = map[string]bool{
@@ -22,12 +23,16 @@ var announcementsMethodsSupported = map[string]bool{
 
 var announcementsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"moderator:manage:announcements"},
+	http.MethodPost:   {scopes.ModeratorManageAnnouncements},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var announcementsTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type PostAnnouncementsRequestBody struct {
 	Message string `json:"message"`
 	Color   string `json:"color"`
@@ -41,6 +46,10 @@ func (e Announcements) GetRequiredScopes(method string) []string {
 	return announcementsScopesByMethod[method]
 }
 
+func (e Announcements) GetAcceptedTokenTypes(method string) []string {
+	return announcementsTokenTypesByMethod[method]
+}
+
 func (e Announcements) ValidMethod(method string) bool {
 	return announcementsMethodsSupported[method]
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var chattersMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var chattersMethodsSupported = map[string]bool{
 }
 
 var chattersScopesByMethod = map[string][]string{
-	http.MethodGet:    {"moderator:read:chatters"},
+	http.MethodGet:    {scopes.ModeratorReadChatters},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var chattersTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type Chatter struct {
 	UserID    string `json:"user_id"`
 	UserLogin string `json:"user_login"`
@@ -42,6 +47,10 @@ func (e Chatters) GetRequiredScopes(method string) []string {
 	return chattersScopesByMethod[method]
 }
 
+func (e Chatters) GetAcceptedTokenTypes(method string) []string {
+	return chattersTokenTypesByMethod[method]
+}
+
 func (e Chatters) ValidMethod(method string) bool {
 	return chattersMethodsSupported[method]
 }
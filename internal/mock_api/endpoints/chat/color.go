@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var colorMethodsSupported = map[string]bool{
@@ -28,7 +29,11 @@ var colorScopesByMethod = map[string][]string{
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
-	http.MethodPut:    {"user:manage:chat_color"},
+	http.MethodPut:    {scopes.UserManageChatColor},
+}
+
+var colorTokenTypesByMethod = map[string][]string{
+	http.MethodPut: {scopes.UserAccessToken},
 }
 
 type GetColorRequestBody struct {
@@ -46,6 +51,10 @@ func (e Color) GetRequiredScopes(method string) []string {
 	return colorScopesByMethod[method]
 }
 
+func (e Color) GetAcceptedTokenTypes(method string) []string {
+	return colorTokenTypesByMethod[method]
+}
+
 func (e Color) ValidMethod(method string) bool {
 	return colorMethodsSupported[method]
 }
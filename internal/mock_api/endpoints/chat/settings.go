@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var settingsMethodsSupported = map[string]bool{
@@ -24,10 +25,14 @@ var settingsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"moderator:manage:chat_settings"},
+	http.MethodPatch:  {scopes.ModeratorManageChatSettings},
 	http.MethodPut:    {},
 }
 
+var settingsTokenTypesByMethod = map[string][]string{
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 // Only used when the user isn't a moderator
 type GetSettingsResponseUnprivileged struct {
 	BroadcasterID                 string `json:"broadcaster_id"`
@@ -76,6 +81,10 @@ func (e Settings) GetRequiredScopes(method string) []string {
 	return settingsScopesByMethod[method]
 }
 
+func (e Settings) GetAcceptedTokenTypes(method string) []string {
+	return settingsTokenTypesByMethod[method]
+}
+
 func (e Settings) ValidMethod(method string) bool {
 	return settingsMethodsSupported[method]
 }
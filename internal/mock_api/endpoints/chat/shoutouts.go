@@ -8,6 +8,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var shoutoutsMethodsSupported = map[string]bool{
@@ -20,12 +21,16 @@ var shoutoutsMethodsSupported = map[string]bool{
 
 var shoutoutsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"moderator:manage:shoutouts"},
+	http.MethodPost:   {scopes.ModeratorManageShoutouts},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var shoutoutsTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type PostShoutoutsRequestBody struct {
 	SlowMode                      *bool `json:"slow_mode"`
 	SlowModeWaitTime              *int  `json:"slow_mode_wait_time"`
@@ -45,6 +50,10 @@ func (e Shoutouts) GetRequiredScopes(method string) []string {
 	return shoutoutsScopesByMethod[method]
 }
 
+func (e Shoutouts) GetAcceptedTokenTypes(method string) []string {
+	return shoutoutsTokenTypesByMethod[method]
+}
+
 func (e Shoutouts) ValidMethod(method string) bool {
 	return shoutoutsMethodsSupported[method]
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -27,12 +28,16 @@ var clipsMethodsSupported = map[string]bool{
 
 var clipsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"clips:edit"},
+	http.MethodPost:   {scopes.ClipsEdit},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var clipsTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type Clips struct{}
 
 type CreateClipsResponse struct {
@@ -46,6 +51,10 @@ func (e Clips) GetRequiredScopes(method string) []string {
 	return clipsScopesByMethod[method]
 }
 
+func (e Clips) GetAcceptedTokenTypes(method string) []string {
+	return clipsTokenTypesByMethod[method]
+}
+
 func (e Clips) ValidMethod(method string) bool {
 	return clipsMethodsSupported[method]
 }
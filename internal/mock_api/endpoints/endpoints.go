@@ -3,7 +3,11 @@
 package endpoints
 
 import (
+	"net/http"
+	"sort"
+
 	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/analytics"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/bits"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/categories"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/ccl"
@@ -29,8 +33,12 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/whispers"
 )
 
+//go:generate go run github.com/twitchdev/twitch-cli mock-api scaffold --spec ${HELIX_OPENAPI} --endpoints-dir . --catalog ../../api/catalog_gen.go
+
 func All() []mock_api.MockEndpoint {
-	return []mock_api.MockEndpoint{
+	return append([]mock_api.MockEndpoint{
+		analytics.ExtensionAnalytics{},
+		analytics.GameAnalytics{},
 		bits.BitsLeaderboard{},
 		bits.Cheermotes{},
 		categories.Games{},
@@ -85,10 +93,34 @@ func All() []mock_api.MockEndpoint {
 		teams.ChannelTeams{},
 		teams.Teams{},
 		users.Blocks{},
+		users.UserExtensions{},
+		users.UserExtensionsList{},
 		users.UsersEndpoint{},
 		videos.Videos{},
 		whispers.Whispers{},
+	}, Generated()...)
+}
+
+// RequiredScopes maps each scope required by a mock endpoint to the endpoints that require it, e.g. "bits:read" to ["GET /bits/leaderboard"]
+func RequiredScopes() map[string][]string {
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	required := map[string][]string{}
+
+	for _, e := range All() {
+		for _, method := range methods {
+			if !e.ValidMethod(method) {
+				continue
+			}
+			for _, scope := range e.GetRequiredScopes(method) {
+				required[scope] = append(required[scope], method+" "+e.Path())
+			}
+		}
+	}
+
+	for scope := range required {
+		sort.Strings(required[scope])
 	}
+	return required
 }
 
 // All these endpoints return 410 Gone
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+// Every scope required by a mock endpoint must be in the scope registry, otherwise tokens for the endpoint can't be created
+func TestRequiredScopesAreKnown(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	required := RequiredScopes()
+	a.NotEmpty(required)
+	a.Contains(required["bits:read"], "GET /bits/leaderboard")
+
+	for scope, endpoints := range required {
+		_, ok := scopes.Get(scope)
+		a.True(ok, "scope %v required by %v is missing from the scope registry", scope, endpoints)
+	}
+}
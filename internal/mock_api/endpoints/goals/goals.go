@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -25,13 +26,17 @@ var goalsMethodsSupported = map[string]bool{
 }
 
 var goalsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:goals"},
+	http.MethodGet:    {scopes.ChannelReadGoals},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var goalsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type Goals struct{}
 
 type GetCreatorGoalsResponse struct {
@@ -52,6 +57,10 @@ func (e Goals) GetRequiredScopes(method string) []string {
 	return goalsScopesByMethod[method]
 }
 
+func (e Goals) GetAcceptedTokenTypes(method string) []string {
+	return goalsTokenTypesByMethod[method]
+}
+
 func (e Goals) ValidMethod(method string) bool {
 	return goalsMethodsSupported[method]
 }
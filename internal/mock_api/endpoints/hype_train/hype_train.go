@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -24,13 +25,17 @@ var hypeTrainEventsMethodsSupported = map[string]bool{
 }
 
 var hypeTrainEventsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:hype_train"},
+	http.MethodGet:    {scopes.ChannelReadHypeTrain},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var hypeTrainEventsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type HypeTrainEvents struct{}
 
 type HypeTrainEventsResponse struct {
@@ -66,6 +71,10 @@ func (e HypeTrainEvents) GetRequiredScopes(method string) []string {
 	return hypeTrainEventsScopesByMethod[method]
 }
 
+func (e HypeTrainEvents) GetAcceptedTokenTypes(method string) []string {
+	return hypeTrainEventsTokenTypesByMethod[method]
+}
+
 func (e HypeTrainEvents) ValidMethod(method string) bool {
 	return hypeTrainEventsMethodsSupported[method]
 }
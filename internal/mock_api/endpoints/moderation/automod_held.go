@@ -9,6 +9,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var automodHeldMethodsSupported = map[string]bool{
@@ -21,12 +22,16 @@ var automodHeldMethodsSupported = map[string]bool{
 
 var automodHeldScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"moderator:manage:automod"},
+	http.MethodPost:   {scopes.ModeratorManageAutomod},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var automodHeldTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type AutomodHeld struct{}
 
 type PostAutomodHeldBody struct {
@@ -41,6 +46,10 @@ func (e AutomodHeld) GetRequiredScopes(method string) []string {
 	return automodHeldScopesByMethod[method]
 }
 
+func (e AutomodHeld) GetAcceptedTokenTypes(method string) []string {
+	return automodHeldTokenTypesByMethod[method]
+}
+
 func (e AutomodHeld) ValidMethod(method string) bool {
 	return automodHeldMethodsSupported[method]
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -23,12 +24,16 @@ var automodStatusMethodsSupported = map[string]bool{
 
 var automodStatusScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"moderation:read"},
+	http.MethodPost:   {scopes.ModerationRead},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var automodStatusTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type AutomodStatus struct{}
 
 type PostAutomodStatusBody struct {
@@ -51,6 +56,10 @@ func (e AutomodStatus) GetRequiredScopes(method string) []string {
 	return automodStatusScopesByMethod[method]
 }
 
+func (e AutomodStatus) GetAcceptedTokenTypes(method string) []string {
+	return automodStatusTokenTypesByMethod[method]
+}
+
 func (e AutomodStatus) ValidMethod(method string) bool {
 	return automodStatusMethodsSupported[method]
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var bannedMethodsSupported = map[string]bool{
@@ -22,13 +23,17 @@ var bannedMethodsSupported = map[string]bool{
 }
 
 var bannedScopesByMethod = map[string][]string{
-	http.MethodGet:    {"moderation:read"},
+	http.MethodGet:    {scopes.ModerationRead},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var bannedTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type Banned struct{}
 
 func (e Banned) Path() string { return "/moderation/banned" }
@@ -37,6 +42,10 @@ func (e Banned) GetRequiredScopes(method string) []string {
 	return bannedScopesByMethod[method]
 }
 
+func (e Banned) GetAcceptedTokenTypes(method string) []string {
+	return bannedTokenTypesByMethod[method]
+}
+
 func (e Banned) ValidMethod(method string) bool {
 	return bannedMethodsSupported[method]
 }
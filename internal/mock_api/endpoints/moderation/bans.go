@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var bansMethodsSupported = map[string]bool{
@@ -24,12 +25,17 @@ var bansMethodsSupported = map[string]bool{
 
 var bansScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"moderator:manage:banned_users"},
-	http.MethodDelete: {"moderator:manage:banned_users"},
+	http.MethodPost:   {scopes.ModeratorManageBannedUsers},
+	http.MethodDelete: {scopes.ModeratorManageBannedUsers},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var bansTokenTypesByMethod = map[string][]string{
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type PostBansRequestBodyData struct {
 	UserID    string `json:"user_id"`
 	Duration  int    `json:"duration"`
@@ -57,6 +63,10 @@ func (e Bans) GetRequiredScopes(method string) []string {
 	return bansScopesByMethod[method]
 }
 
+func (e Bans) GetAcceptedTokenTypes(method string) []string {
+	return bansTokenTypesByMethod[method]
+}
+
 func (e Bans) ValidMethod(method string) bool {
 	return bansMethodsSupported[method]
 }
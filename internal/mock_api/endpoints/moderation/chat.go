@@ -8,6 +8,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var chatMethodsSupported = map[string]bool{
@@ -21,11 +22,15 @@ var chatMethodsSupported = map[string]bool{
 var chatScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
 	http.MethodPost:   {},
-	http.MethodDelete: {"moderator:manage:chat_messages"},
+	http.MethodDelete: {scopes.ModeratorManageChatMessages},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var chatTokenTypesByMethod = map[string][]string{
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type Chat struct{}
 
 func (e Chat) Path() string { return "/moderation/chat" }
@@ -34,6 +39,10 @@ func (e Chat) GetRequiredScopes(method string) []string {
 	return chatScopesByMethod[method]
 }
 
+func (e Chat) GetAcceptedTokenTypes(method string) []string {
+	return chatTokenTypesByMethod[method]
+}
+
 func (e Chat) ValidMethod(method string) bool {
 	return chatMethodsSupported[method]
 }
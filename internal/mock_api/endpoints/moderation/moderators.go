@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var moderatorsMethodsSupported = map[string]bool{
@@ -22,13 +23,19 @@ var moderatorsMethodsSupported = map[string]bool{
 }
 
 var moderatorsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"moderation:read", "channel:manage:moderators"},
-	http.MethodPost:   {"channel:manage:moderators"},
-	http.MethodDelete: {"channel:manage:moderators"},
+	http.MethodGet:    {scopes.ModerationRead, scopes.ChannelManageModerators},
+	http.MethodPost:   {scopes.ChannelManageModerators},
+	http.MethodDelete: {scopes.ChannelManageModerators},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var moderatorsTokenTypesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.UserAccessToken},
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type Moderators struct{}
 
 func (e Moderators) Path() string { return "/moderation/moderators" }
@@ -37,6 +44,10 @@ func (e Moderators) GetRequiredScopes(method string) []string {
 	return moderatorsScopesByMethod[method]
 }
 
+func (e Moderators) GetAcceptedTokenTypes(method string) []string {
+	return moderatorsTokenTypesByMethod[method]
+}
+
 func (e Moderators) ValidMethod(method string) bool {
 	return moderatorsMethodsSupported[method]
 }
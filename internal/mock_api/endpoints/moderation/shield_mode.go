@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -25,11 +26,16 @@ var shieldModeMethodsSupported = map[string]bool{
 }
 
 var shieldModeScopesByMethod = map[string][]string{
-	http.MethodGet:    {"moderator:manage:shield_mode", "moderator:read:shield_mode"},
+	http.MethodGet:    {scopes.ModeratorManageShieldMode, scopes.ModeratorReadShieldMode},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
-	http.MethodPut:    {"moderator:manage:shield_mode"},
+	http.MethodPut:    {scopes.ModeratorManageShieldMode},
+}
+
+var shieldModeTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+	http.MethodPut: {scopes.UserAccessToken},
 }
 
 type GetShieldModeStatusResponseBody struct {
@@ -52,6 +58,10 @@ func (e ShieldMode) GetRequiredScopes(method string) []string {
 	return shieldModeScopesByMethod[method]
 }
 
+func (e ShieldMode) GetAcceptedTokenTypes(method string) []string {
+	return shieldModeTokenTypesByMethod[method]
+}
+
 func (e ShieldMode) ValidMethod(method string) bool {
 	return shieldModeMethodsSupported[method]
 }
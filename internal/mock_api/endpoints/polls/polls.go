@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -23,13 +24,19 @@ var pollsMethodsSupported = map[string]bool{
 }
 
 var pollsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:polls", "channel:manage:polls"},
-	http.MethodPost:   {"channel:manage:polls"},
+	http.MethodGet:    {scopes.ChannelReadPolls, scopes.ChannelManagePolls},
+	http.MethodPost:   {scopes.ChannelManagePolls},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"channel:manage:polls"},
+	http.MethodPatch:  {scopes.ChannelManagePolls},
 	http.MethodPut:    {},
 }
 
+var pollsTokenTypesByMethod = map[string][]string{
+	http.MethodGet:   {scopes.UserAccessToken},
+	http.MethodPost:  {scopes.UserAccessToken},
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 type Polls struct{}
 
 type PostPollsBody struct {
@@ -59,6 +66,10 @@ func (e Polls) GetRequiredScopes(method string) []string {
 	return pollsScopesByMethod[method]
 }
 
+func (e Polls) GetAcceptedTokenTypes(method string) []string {
+	return pollsTokenTypesByMethod[method]
+}
+
 func (e Polls) ValidMethod(method string) bool {
 	return pollsMethodsSupported[method]
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -24,13 +25,19 @@ var predictionsMethodsSupported = map[string]bool{
 }
 
 var predictionsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:predictions", "channel:manage:predictions"},
-	http.MethodPost:   {"channel:manage:predictions"},
+	http.MethodGet:    {scopes.ChannelReadPredictions, scopes.ChannelManagePredictions},
+	http.MethodPost:   {scopes.ChannelManagePredictions},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"channel:manage:predictions"},
+	http.MethodPatch:  {scopes.ChannelManagePredictions},
 	http.MethodPut:    {},
 }
 
+var predictionsTokenTypesByMethod = map[string][]string{
+	http.MethodGet:   {scopes.UserAccessToken},
+	http.MethodPost:  {scopes.UserAccessToken},
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 type Predictions struct{}
 
 type PostPredictionsBody struct {
@@ -57,6 +64,10 @@ func (e Predictions) GetRequiredScopes(method string) []string {
 	return predictionsScopesByMethod[method]
 }
 
+func (e Predictions) GetAcceptedTokenTypes(method string) []string {
+	return predictionsTokenTypesByMethod[method]
+}
+
 func (e Predictions) ValidMethod(method string) bool {
 	return predictionsMethodsSupported[method]
 }
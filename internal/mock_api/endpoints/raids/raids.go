@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -25,12 +26,17 @@ var raidsMethodsSupported = map[string]bool{
 
 var raidsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"channel:manage:raids"},
-	http.MethodDelete: {"channel:manage:raids"},
+	http.MethodPost:   {scopes.ChannelManageRaids},
+	http.MethodDelete: {scopes.ChannelManageRaids},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var raidsTokenTypesByMethod = map[string][]string{
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type GetVIPsResponseBody struct {
 	CreatedAt string `json:"created_at"`
 	IsMature  bool   `json:"is_mature"`
@@ -44,6 +50,10 @@ func (e Raids) GetRequiredScopes(method string) []string {
 	return raidsScopesByMethod[method]
 }
 
+func (e Raids) GetAcceptedTokenTypes(method string) []string {
+	return raidsTokenTypesByMethod[method]
+}
+
 func (e Raids) ValidMethod(method string) bool {
 	return raidsMethodsSupported[method]
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -27,12 +28,18 @@ var scheduleSegmentMethodsSupported = map[string]bool{
 
 var scheduleSegmentScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"channel:manage:schedule"},
-	http.MethodDelete: {"channel:manage:schedule"},
-	http.MethodPatch:  {"channel:manage:schedule"},
+	http.MethodPost:   {scopes.ChannelManageSchedule},
+	http.MethodDelete: {scopes.ChannelManageSchedule},
+	http.MethodPatch:  {scopes.ChannelManageSchedule},
 	http.MethodPut:    {},
 }
 
+var scheduleSegmentTokenTypesByMethod = map[string][]string{
+	http.MethodPost:   {scopes.UserAccessToken},
+	http.MethodPatch:  {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 var f = false
 
 type ScheduleSegment struct{}
@@ -53,6 +60,10 @@ func (e ScheduleSegment) GetRequiredScopes(method string) []string {
 	return scheduleSegmentScopesByMethod[method]
 }
 
+func (e ScheduleSegment) GetAcceptedTokenTypes(method string) []string {
+	return scheduleSegmentTokenTypesByMethod[method]
+}
+
 func (e ScheduleSegment) ValidMethod(method string) bool {
 	return scheduleSegmentMethodsSupported[method]
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -27,10 +28,14 @@ var scheduleSettingsScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
-	http.MethodPatch:  {"channel:manage:schedule"},
+	http.MethodPatch:  {scopes.ChannelManageSchedule},
 	http.MethodPut:    {},
 }
 
+var scheduleSettingsTokenTypesByMethod = map[string][]string{
+	http.MethodPatch: {scopes.UserAccessToken},
+}
+
 type ScheduleSettings struct{}
 
 type PatchSettingsBody struct {
@@ -46,6 +51,10 @@ func (e ScheduleSettings) GetRequiredScopes(method string) []string {
 	return scheduleSettingsScopesByMethod[method]
 }
 
+func (e ScheduleSettings) GetAcceptedTokenTypes(method string) []string {
+	return scheduleSettingsTokenTypesByMethod[method]
+}
+
 func (e ScheduleSettings) ValidMethod(method string) bool {
 	return scheduleSettingsMethodsSupported[method]
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var followedStreamsMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var followedStreamsMethodsSupported = map[string]bool{
 }
 
 var followedStreamsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"user:read:follows", "user:edit:follows"},
+	http.MethodGet:    {scopes.UserReadFollows, scopes.UserEditFollows},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var followedStreamsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type FollowedStreams struct{}
 
 func (e FollowedStreams) Path() string { return "/streams/followed" }
@@ -36,6 +41,10 @@ func (e FollowedStreams) GetRequiredScopes(method string) []string {
 	return followedStreamsScopesByMethod[method]
 }
 
+func (e FollowedStreams) GetAcceptedTokenTypes(method string) []string {
+	return followedStreamsTokenTypesByMethod[method]
+}
+
 func (e FollowedStreams) ValidMethod(method string) bool {
 	return followedStreamsMethodsSupported[method]
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -23,13 +24,18 @@ var markersMethodsSupported = map[string]bool{
 }
 
 var markersScopesByMethod = map[string][]string{
-	http.MethodGet:    {"user:read:broadcast", "channel:manage:broadcast"},
-	http.MethodPost:   {"channel:manage:broadcast"},
+	http.MethodGet:    {scopes.UserReadBroadcast, scopes.ChannelManageBroadcast},
+	http.MethodPost:   {scopes.ChannelManageBroadcast},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var markersTokenTypesByMethod = map[string][]string{
+	http.MethodGet:  {scopes.UserAccessToken},
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type Markers struct{}
 
 type MarkerPostBody struct {
@@ -50,6 +56,10 @@ func (e Markers) GetRequiredScopes(method string) []string {
 	return markersScopesByMethod[method]
 }
 
+func (e Markers) GetAcceptedTokenTypes(method string) []string {
+	return markersTokenTypesByMethod[method]
+}
+
 func (e Markers) ValidMethod(method string) bool {
 	return markersMethodsSupported[method]
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -23,13 +24,17 @@ var streamKeyMethodsSupported = map[string]bool{
 }
 
 var streamKeyScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:stream_key"},
+	http.MethodGet:    {scopes.ChannelReadStreamKey},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var streamKeyTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type StreamKey struct{}
 
 type StreamKeyResponse struct {
@@ -42,6 +47,10 @@ func (e StreamKey) GetRequiredScopes(method string) []string {
 	return streamKeyScopesByMethod[method]
 }
 
+func (e StreamKey) GetAcceptedTokenTypes(method string) []string {
+	return streamKeyTokenTypesByMethod[method]
+}
+
 func (e StreamKey) ValidMethod(method string) bool {
 	return streamKeyMethodsSupported[method]
 }
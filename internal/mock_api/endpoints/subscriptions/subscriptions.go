@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var broadcasterSubscriptionsMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var broadcasterSubscriptionsMethodsSupported = map[string]bool{
 }
 
 var broadcasterSubscriptionsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"channel:read:subscriptions"},
+	http.MethodGet:    {scopes.ChannelReadSubscriptions},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var broadcasterSubscriptionsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type BroadcasterSubscriptions struct{}
 
 func (e BroadcasterSubscriptions) Path() string { return "/subscriptions" }
@@ -36,6 +41,10 @@ func (e BroadcasterSubscriptions) GetRequiredScopes(method string) []string {
 	return broadcasterSubscriptionsScopesByMethod[method]
 }
 
+func (e BroadcasterSubscriptions) GetAcceptedTokenTypes(method string) []string {
+	return broadcasterSubscriptionsTokenTypesByMethod[method]
+}
+
 func (e BroadcasterSubscriptions) ValidMethod(method string) bool {
 	return broadcasterSubscriptionsMethodsSupported[method]
 }
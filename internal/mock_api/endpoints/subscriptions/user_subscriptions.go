@@ -10,6 +10,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var userSubscriptionsMethodsSupported = map[string]bool{
@@ -21,13 +22,17 @@ var userSubscriptionsMethodsSupported = map[string]bool{
 }
 
 var userSubscriptionsScopesByMethod = map[string][]string{
-	http.MethodGet:    {"user:read:subscriptions"},
+	http.MethodGet:    {scopes.UserReadSubscriptions},
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var userSubscriptionsTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
 type UserSubscriptions struct{}
 
 func (e UserSubscriptions) Path() string { return "/subscriptions/user" }
@@ -36,6 +41,10 @@ func (e UserSubscriptions) GetRequiredScopes(method string) []string {
 	return userSubscriptionsScopesByMethod[method]
 }
 
+func (e UserSubscriptions) GetAcceptedTokenTypes(method string) []string {
+	return userSubscriptionsTokenTypesByMethod[method]
+}
+
 func (e UserSubscriptions) ValidMethod(method string) bool {
 	return userSubscriptionsMethodsSupported[method]
 }
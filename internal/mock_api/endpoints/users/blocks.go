@@ -13,6 +13,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var blocksMethodsSupported = map[string]bool{
@@ -24,11 +25,17 @@ var blocksMethodsSupported = map[string]bool{
 }
 
 var blocksScopesByMethod = map[string][]string{
-	http.MethodGet:    {"user:read:blocked_users", "user:manage:blocked_users"},
+	http.MethodGet:    {scopes.UserReadBlockedUsers, scopes.UserManageBlockedUsers},
 	http.MethodPost:   {},
-	http.MethodDelete: {"user:manage:blocked_users"},
+	http.MethodDelete: {scopes.UserManageBlockedUsers},
 	http.MethodPatch:  {},
-	http.MethodPut:    {"user:manage:blocked_users"},
+	http.MethodPut:    {scopes.UserManageBlockedUsers},
+}
+
+var blocksTokenTypesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.UserAccessToken},
+	http.MethodPut:    {scopes.UserAccessToken},
+	http.MethodDelete: {scopes.UserAccessToken},
 }
 
 type Blocks struct{}
@@ -39,6 +46,10 @@ func (e Blocks) GetRequiredScopes(method string) []string {
 	return blocksScopesByMethod[method]
 }
 
+func (e Blocks) GetAcceptedTokenTypes(method string) []string {
+	return blocksTokenTypesByMethod[method]
+}
+
 func (e Blocks) ValidMethod(method string) bool {
 	return blocksMethodsSupported[method]
 }
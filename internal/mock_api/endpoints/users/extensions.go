@@ -0,0 +1,350 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package users
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+)
+
+var userExtensionsListMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    false,
+}
+
+var userExtensionsListScopesByMethod = map[string][]string{
+	http.MethodGet:    {scopes.UserReadBroadcast, scopes.UserEditBroadcast},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {},
+}
+
+var userExtensionsListTokenTypesByMethod = map[string][]string{
+	http.MethodGet: {scopes.UserAccessToken},
+}
+
+var userExtensionsMethodsSupported = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   false,
+	http.MethodDelete: false,
+	http.MethodPatch:  false,
+	http.MethodPut:    true,
+}
+
+var userExtensionsScopesByMethod = map[string][]string{
+	http.MethodGet:    {},
+	http.MethodPost:   {},
+	http.MethodDelete: {},
+	http.MethodPatch:  {},
+	http.MethodPut:    {scopes.UserEditBroadcast, scopes.ChannelManageExtensions},
+}
+
+var userExtensionsTokenTypesByMethod = map[string][]string{
+	http.MethodPut: {scopes.UserAccessToken},
+}
+
+// extensionSlotCounts is the number of slots a channel has for each type of extension
+var extensionSlotCounts = map[string]int{
+	"panel":     3,
+	"overlay":   1,
+	"component": 2,
+}
+
+const maxComponentX = 8000
+const maxComponentY = 5000
+
+type UserExtensionsList struct{}
+
+type UserExtensions struct{}
+
+type InstalledExtension struct {
+	ID          string   `json:"id"`
+	Version     string   `json:"version"`
+	Name        string   `json:"name"`
+	CanActivate bool     `json:"can_activate"`
+	Type        []string `json:"type"`
+}
+
+type ActiveExtension struct {
+	Active  bool   `json:"active"`
+	ID      string `json:"id,omitempty"`
+	Version string `json:"version,omitempty"`
+	Name    string `json:"name,omitempty"`
+	X       *int   `json:"x,omitempty"`
+	Y       *int   `json:"y,omitempty"`
+}
+
+type ActiveExtensions struct {
+	Panel     map[string]ActiveExtension `json:"panel"`
+	Overlay   map[string]ActiveExtension `json:"overlay"`
+	Component map[string]ActiveExtension `json:"component"`
+}
+
+type PutUserExtensionsRequestBody struct {
+	Data map[string]map[string]ActiveExtension `json:"data"`
+}
+
+func (e UserExtensionsList) Path() string { return "/users/extensions/list" }
+
+func (e UserExtensionsList) GetRequiredScopes(method string) []string {
+	return userExtensionsListScopesByMethod[method]
+}
+
+func (e UserExtensionsList) GetAcceptedTokenTypes(method string) []string {
+	return userExtensionsListTokenTypesByMethod[method]
+}
+
+func (e UserExtensionsList) ValidMethod(method string) bool {
+	return userExtensionsListMethodsSupported[method]
+}
+
+func (e UserExtensionsList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	switch r.Method {
+	case http.MethodGet:
+		getUserExtensionsList(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (e UserExtensions) Path() string { return "/users/extensions" }
+
+func (e UserExtensions) GetRequiredScopes(method string) []string {
+	return userExtensionsScopesByMethod[method]
+}
+
+func (e UserExtensions) GetAcceptedTokenTypes(method string) []string {
+	return userExtensionsTokenTypesByMethod[method]
+}
+
+func (e UserExtensions) ValidMethod(method string) bool {
+	return userExtensionsMethodsSupported[method]
+}
+
+func (e UserExtensions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	switch r.Method {
+	case http.MethodGet:
+		getUserExtensions(w, r)
+	case http.MethodPut:
+		putUserExtensions(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getUserExtensionsList(w http.ResponseWriter, r *http.Request) {
+	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
+
+	installed, err := db.NewQuery(r, 100).GetUserInstalledExtensions(userCtx.UserID)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	extensions := []InstalledExtension{}
+	for _, e := range installed {
+		extensions = append(extensions, InstalledExtension{
+			ID:          e.ID,
+			Version:     e.Version,
+			Name:        e.Name,
+			CanActivate: e.CanActivate,
+			Type:        e.GetTypes(),
+		})
+	}
+
+	bytes, _ := json.Marshal(models.APIResponse{Data: extensions})
+	w.Write(bytes)
+}
+
+func getUserExtensions(w http.ResponseWriter, r *http.Request) {
+	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		userID = userCtx.UserID
+	}
+	if userID == "" {
+		mock_errors.WriteBadRequest(w, "Missing user_id")
+		return
+	}
+
+	writeActiveExtensions(w, r, userID)
+}
+
+func putUserExtensions(w http.ResponseWriter, r *http.Request) {
+	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
+
+	var body PutUserExtensionsRequestBody
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "error parsing body")
+		return
+	}
+	if len(body.Data) == 0 {
+		mock_errors.WriteBadRequest(w, "Missing data")
+		return
+	}
+
+	installed, err := db.NewQuery(r, 100).GetUserInstalledExtensions(userCtx.UserID)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	installedByID := map[string]database.Extension{}
+	for _, e := range installed {
+		installedByID[e.ID] = e
+	}
+
+	activate := []database.UserExtensionSlot{}
+	deactivate := []database.UserExtensionSlot{}
+	for slotType, slots := range body.Data {
+		slotCount, ok := extensionSlotCounts[slotType]
+		if !ok {
+			mock_errors.WriteBadRequest(w, fmt.Sprintf("Invalid extension type %v; must be one of panel, overlay, or component", slotType))
+			return
+		}
+
+		seen := map[string]bool{}
+		for slot, ae := range slots {
+			if !isValidSlot(slot, slotCount) {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Invalid %v slot %v; must be between 1 and %v", slotType, slot, slotCount))
+				return
+			}
+
+			s := database.UserExtensionSlot{
+				SlotType:    slotType,
+				Slot:        slot,
+				ExtensionID: ae.ID,
+			}
+
+			if !ae.Active {
+				deactivate = append(deactivate, s)
+				continue
+			}
+
+			if ae.ID == "" || ae.Version == "" {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Active extensions must specify an id and version (%v slot %v)", slotType, slot))
+				return
+			}
+
+			e, ok := installedByID[ae.ID]
+			if !ok {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Extension %v is not installed", ae.ID))
+				return
+			}
+			if e.Version != ae.Version {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Extension %v is not installed at version %v", ae.ID, ae.Version))
+				return
+			}
+			if !e.CanActivate {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Extension %v cannot be activated", ae.ID))
+				return
+			}
+			if !e.SupportsType(slotType) {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Extension %v does not support the %v type", ae.ID, slotType))
+				return
+			}
+			if seen[ae.ID] {
+				mock_errors.WriteBadRequest(w, fmt.Sprintf("Extension %v can only be activated in one %v slot", ae.ID, slotType))
+				return
+			}
+			seen[ae.ID] = true
+
+			if slotType == "component" {
+				if ae.X == nil || ae.Y == nil {
+					mock_errors.WriteBadRequest(w, fmt.Sprintf("Component extensions must specify x and y (component slot %v)", slot))
+					return
+				}
+				if *ae.X < 0 || *ae.X > maxComponentX || *ae.Y < 0 || *ae.Y > maxComponentY {
+					mock_errors.WriteBadRequest(w, fmt.Sprintf("Component coordinates must be within 0-%v for x and 0-%v for y", maxComponentX, maxComponentY))
+					return
+				}
+				s.X = sql.NullInt64{Int64: int64(*ae.X), Valid: true}
+				s.Y = sql.NullInt64{Int64: int64(*ae.Y), Valid: true}
+			}
+
+			activate = append(activate, s)
+		}
+	}
+
+	err = db.NewQuery(r, 100).UpdateUserExtensionSlots(userCtx.UserID, activate, deactivate)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	writeActiveExtensions(w, r, userCtx.UserID)
+}
+
+func writeActiveExtensions(w http.ResponseWriter, r *http.Request, userID string) {
+	slots, err := db.NewQuery(r, 100).GetUserExtensionSlots(userID)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	active := ActiveExtensions{
+		Panel:     emptySlots("panel"),
+		Overlay:   emptySlots("overlay"),
+		Component: emptySlots("component"),
+	}
+	byType := map[string]map[string]ActiveExtension{
+		"panel":     active.Panel,
+		"overlay":   active.Overlay,
+		"component": active.Component,
+	}
+
+	for _, s := range slots {
+		ae := ActiveExtension{
+			Active:  true,
+			ID:      s.ExtensionID,
+			Version: s.Version,
+			Name:    s.ExtensionName,
+		}
+		if s.X.Valid && s.Y.Valid {
+			x := int(s.X.Int64)
+			y := int(s.Y.Int64)
+			ae.X = &x
+			ae.Y = &y
+		}
+		if m, ok := byType[s.SlotType]; ok {
+			m[s.Slot] = ae
+		}
+	}
+
+	bytes, _ := json.Marshal(models.APIResponse{Data: active})
+	w.Write(bytes)
+}
+
+func emptySlots(slotType string) map[string]ActiveExtension {
+	m := map[string]ActiveExtension{}
+	for i := 1; i <= extensionSlotCounts[slotType]; i++ {
+		m[fmt.Sprint(i)] = ActiveExtension{Active: false}
+	}
+	return m
+}
+
+func isValidSlot(slot string, count int) bool {
+	for i := 1; i <= count; i++ {
+		if slot == fmt.Sprint(i) {
+			return true
+		}
+	}
+	return false
+}
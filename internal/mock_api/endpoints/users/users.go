@@ -11,6 +11,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var userMethodsSupported = map[string]bool{
@@ -27,10 +28,14 @@ var userScopesByMethod = map[string][]string{
 	http.MethodPost:   {},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
-	http.MethodPut:    {"user:edit"},
+	http.MethodPut:    {scopes.UserEdit},
 	"OPTIONS": {},
 }
 
+var userTokenTypesByMethod = map[string][]string{
+	http.MethodPut: {scopes.UserAccessToken},
+}
+
 type User struct {
 	ID              string `db:"id" json:"id"`
 	UserLogin       string `db:"user_login" json:"login"`
@@ -54,6 +59,10 @@ func (e UsersEndpoint) GetRequiredScopes(method string) []string {
 	return userScopesByMethod[method]
 }
 
+func (e UsersEndpoint) GetAcceptedTokenTypes(method string) []string {
+	return userTokenTypesByMethod[method]
+}
+
 func (e UsersEndpoint) ValidMethod(method string) bool {
 	return userMethodsSupported[method]
 }
@@ -80,7 +89,7 @@ func getUsers(w http.ResponseWriter, r *http.Request) {
 	logins := q["login"]
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 
-	shouldIncludeEmail := userCtx.HasScope("user:read:email")
+	shouldIncludeEmail := userCtx.HasScope(scopes.UserReadEmail)
 
 	if len(logins) == 0 && len(userIDs) == 0 && len(userCtx.UserID) == 0 {
 		log.Print(userCtx)
@@ -149,7 +158,7 @@ func putUsers(w http.ResponseWriter, r *http.Request) {
 	userCtx := r.Context().Value("auth").(authentication.UserAuthentication)
 	description := q.Get("description")
 
-	shouldIncludeEmail := userCtx.HasScope("user:read:email")
+	shouldIncludeEmail := userCtx.HasScope(scopes.UserReadEmail)
 
 	if userCtx.UserID == "" || len(description) == 0 {
 		mock_errors.WriteBadRequest(w, "description is required")
@@ -3,9 +3,14 @@
 package users
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
 
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
 	"github.com/twitchdev/twitch-cli/test_setup"
 	"github.com/twitchdev/twitch-cli/test_setup/test_server"
 )
@@ -113,3 +118,95 @@ func TestBlocks(t *testing.T) {
 	a.Nil(err)
 	a.Equal(204, resp.StatusCode)
 }
+
+func TestUserExtensions(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	db, err := database.NewConnection(true)
+	a.Nil(err)
+	client, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{ID: util.RandomClientID(), Name: "test", IsExtension: true}, false)
+	a.Nil(err)
+	e := database.Extension{ID: client.ID, Version: "1.0.0", Name: "test", Types: "panel,component", CanActivate: true}
+	a.Nil(db.NewQuery(nil, 100).InsertExtension(e))
+	a.Nil(db.NewQuery(nil, 100).InstallUserExtension("1", e.ID))
+	db.DB.Close()
+
+	// list
+	ts := test_server.SetupTestServer(UserExtensionsList{})
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+UserExtensionsList{}.Path(), nil)
+	resp, err := http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var list struct {
+		Data []InstalledExtension `json:"data"`
+	}
+	a.Nil(json.Unmarshal(body, &list))
+	a.Contains(list.Data, InstalledExtension{ID: e.ID, Version: "1.0.0", Name: "test", CanActivate: true, Type: []string{"panel", "component"}})
+
+	// get
+	ts = test_server.SetupTestServer(UserExtensions{})
+	req, _ = http.NewRequest(http.MethodGet, ts.URL+UserExtensions{}.Path(), nil)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	// put
+	put := func(body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPut, ts.URL+UserExtensions{}.Path(), bytes.NewBufferString(body))
+		resp, err := http.DefaultClient.Do(req)
+		a.Nil(err)
+		return resp
+	}
+
+	resp = put(`{}`)
+	a.Equal(400, resp.StatusCode)
+
+	// invalid slot
+	resp = put(`{"data":{"panel":{"4":{"active":true,"id":"` + e.ID + `","version":"1.0.0"}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	// invalid type for extension
+	resp = put(`{"data":{"overlay":{"1":{"active":true,"id":"` + e.ID + `","version":"1.0.0"}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	// version mismatch
+	resp = put(`{"data":{"panel":{"1":{"active":true,"id":"` + e.ID + `","version":"2.0.0"}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	// not installed
+	resp = put(`{"data":{"panel":{"1":{"active":true,"id":"potato","version":"1.0.0"}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	// components require coordinates
+	resp = put(`{"data":{"component":{"1":{"active":true,"id":"` + e.ID + `","version":"1.0.0"}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	resp = put(`{"data":{"component":{"1":{"active":true,"id":"` + e.ID + `","version":"1.0.0","x":9000,"y":0}}}}`)
+	a.Equal(400, resp.StatusCode)
+
+	// the test database persists between runs, so explicitly clear panel 1
+	resp = put(`{"data":{"panel":{"1":{"active":false},"2":{"active":true,"id":"` + e.ID + `","version":"1.0.0"}},"component":{"1":{"active":true,"id":"` + e.ID + `","version":"1.0.0","x":10,"y":20}}}}`)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ = io.ReadAll(resp.Body)
+	var active struct {
+		Data ActiveExtensions `json:"data"`
+	}
+	a.Nil(json.Unmarshal(body, &active))
+	a.True(active.Data.Panel["2"].Active)
+	a.Equal(e.ID, active.Data.Panel["2"].ID)
+	a.False(active.Data.Panel["1"].Active)
+	a.Equal(10, *active.Data.Component["1"].X)
+	a.Equal(20, *active.Data.Component["1"].Y)
+	a.Len(active.Data.Overlay, 1)
+
+	resp = put(`{"data":{"panel":{"2":{"active":false}}}}`)
+	a.Equal(200, resp.StatusCode)
+
+	body, _ = io.ReadAll(resp.Body)
+	a.Nil(json.Unmarshal(body, &active))
+	a.False(active.Data.Panel["2"].Active)
+	a.True(active.Data.Component["1"].Active)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 	"github.com/twitchdev/twitch-cli/internal/models"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 	"github.com/twitchdev/twitch-cli/internal/util"
 )
 
@@ -26,11 +27,15 @@ var videosMethodsSupported = map[string]bool{
 var videosScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
 	http.MethodPost:   {},
-	http.MethodDelete: {"channel:manage:videos"},
+	http.MethodDelete: {scopes.ChannelManageVideos},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var videosTokenTypesByMethod = map[string][]string{
+	http.MethodDelete: {scopes.UserAccessToken},
+}
+
 type Videos struct{}
 
 var periodDurationMapping = map[string]time.Duration{
@@ -46,6 +51,10 @@ func (e Videos) GetRequiredScopes(method string) []string {
 	return videosScopesByMethod[method]
 }
 
+func (e Videos) GetAcceptedTokenTypes(method string) []string {
+	return videosTokenTypesByMethod[method]
+}
+
 func (e Videos) ValidMethod(method string) bool {
 	return videosMethodsSupported[method]
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
 )
 
 var whispersMethodsSupported = map[string]bool{
@@ -21,12 +22,16 @@ var whispersMethodsSupported = map[string]bool{
 
 var whispersScopesByMethod = map[string][]string{
 	http.MethodGet:    {},
-	http.MethodPost:   {"user:manage:whispers"},
+	http.MethodPost:   {scopes.UserManageWhispers},
 	http.MethodDelete: {},
 	http.MethodPatch:  {},
 	http.MethodPut:    {},
 }
 
+var whispersTokenTypesByMethod = map[string][]string{
+	http.MethodPost: {scopes.UserAccessToken},
+}
+
 type PostWhisperRequestBody struct {
 	Message string `json:"message"`
 }
@@ -39,6 +44,10 @@ func (e Whispers) GetRequiredScopes(method string) []string {
 	return whispersScopesByMethod[method]
 }
 
+func (e Whispers) GetAcceptedTokenTypes(method string) []string {
+	return whispersTokenTypesByMethod[method]
+}
+
 func (e Whispers) ValidMethod(method string) bool {
 	return whispersMethodsSupported[method]
 }
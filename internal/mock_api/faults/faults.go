@@ -0,0 +1,242 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package faults
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Rule describes a fault to inject into matching requests. Empty match fields match any request.
+type Rule struct {
+	ID string `json:"id"`
+
+	// match criteria
+	Method   string            `json:"method,omitempty"`
+	Path     string            `json:"path,omitempty"`
+	Query    map[string]string `json:"query,omitempty"`
+	ClientID string            `json:"client_id,omitempty"`
+
+	// effects
+	Status      int     `json:"status,omitempty"`
+	Message     string  `json:"message,omitempty"`
+	Latency     string  `json:"latency,omitempty"`
+	Probability float64 `json:"probability,omitempty"`
+	CorruptBody bool    `json:"corrupt_body,omitempty"`
+
+	latency time.Duration
+}
+
+type rulesFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+type Engine struct {
+	mu    sync.RWMutex
+	rules []Rule
+	roll  func() float64
+}
+
+func NewEngine() *Engine {
+	return &Engine{
+		rules: []Rule{},
+		roll:  rand.Float64,
+	}
+}
+
+// LoadFile adds every rule in a JSON file in the format of {"rules": [...]}
+func (e *Engine) LoadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var f rulesFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("Error parsing fault rules: %v", err)
+	}
+
+	for _, r := range f.Rules {
+		if _, err := e.Add(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add validates the rule and appends it to the list of rules, returning the rule with its ID populated
+func (e *Engine) Add(r Rule) (Rule, error) {
+	if r.Status == 0 && r.Latency == "" && !r.CorruptBody {
+		return r, errors.New("Fault rules must define at least one of status, latency, or corrupt_body")
+	}
+	if r.Status != 0 && (r.Status < 400 || r.Status > 599) {
+		return r, fmt.Errorf("Invalid status %v; must be between 400 and 599", r.Status)
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return r, fmt.Errorf("Invalid probability %v; must be between 0 and 1", r.Probability)
+	}
+	if r.Latency != "" {
+		l, err := time.ParseDuration(r.Latency)
+		if err != nil || l < 0 {
+			return r, fmt.Errorf("Invalid latency %q", r.Latency)
+		}
+		r.latency = l
+	}
+	if r.Path != "" && !strings.HasPrefix(r.Path, "/") {
+		r.Path = "/" + r.Path
+	}
+	r.Method = strings.ToUpper(r.Method)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if r.ID == "" {
+		r.ID = util.RandomGUID()
+	}
+	for _, existing := range e.rules {
+		if existing.ID == r.ID {
+			return r, fmt.Errorf("A fault rule with the id %v already exists", r.ID)
+		}
+	}
+	e.rules = append(e.rules, r)
+
+	return r, nil
+}
+
+// Remove deletes the rule with the given ID, returning false if no such rule exists
+func (e *Engine) Remove(id string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for i, r := range e.rules {
+		if r.ID == id {
+			e.rules = append(e.rules[:i], e.rules[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) Clear() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rules = []Rule{}
+}
+
+func (e *Engine) Rules() []Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	rules := make([]Rule, len(e.rules))
+	copy(rules, e.rules)
+	return rules
+}
+
+// Middleware applies the first matching rule to requests for the given endpoint path. Rules are evaluated on every request,
+// so rules added at runtime take effect immediately.
+func (e *Engine) Middleware(path string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rule, ok := e.match(path, r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if rule.latency > 0 {
+			time.Sleep(rule.latency)
+		}
+
+		if rule.Status != 0 {
+			message := rule.Message
+			if message == "" {
+				message = "Injected fault"
+			}
+			w.WriteHeader(rule.Status)
+			w.Write(mock_errors.GetErrorBytes(rule.Status, errors.New(http.StatusText(rule.Status)), message))
+			return
+		}
+
+		if rule.CorruptBody {
+			cw := &captureWriter{header: w.Header(), status: http.StatusOK}
+			next.ServeHTTP(cw, r)
+			w.WriteHeader(cw.status)
+			w.Write(corrupt(cw.body.Bytes()))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (e *Engine) match(path string, r *http.Request) (Rule, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, rule := range e.rules {
+		if rule.Method != "" && rule.Method != r.Method {
+			continue
+		}
+		if rule.Path != "" && !matchPath(rule.Path, path) {
+			continue
+		}
+		if rule.ClientID != "" && rule.ClientID != r.Header.Get("Client-ID") {
+			continue
+		}
+
+		queryMatches := true
+		for k, v := range rule.Query {
+			if r.URL.Query().Get(k) != v {
+				queryMatches = false
+				break
+			}
+		}
+		if !queryMatches {
+			continue
+		}
+
+		if rule.Probability != 0 && e.roll() >= rule.Probability {
+			continue
+		}
+		return rule, true
+	}
+	return Rule{}, false
+}
+
+// matchPath supports exact matches, or prefix matches when the rule path ends in *
+func matchPath(rulePath string, path string) bool {
+	if strings.HasSuffix(rulePath, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(rulePath, "*"))
+	}
+	return rulePath == path
+}
+
+// corrupt truncates the body partway through, leaving JSON that fails to parse
+func corrupt(body []byte) []byte {
+	if len(body) < 2 {
+		return []byte("{")
+	}
+	return body[:len(body)/2]
+}
+
+type captureWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (c *captureWriter) Header() http.Header { return c.header }
+
+func (c *captureWriter) Write(b []byte) (int, error) { return c.body.Write(b) }
+
+func (c *captureWriter) WriteHeader(status int) { c.status = status }
@@ -0,0 +1,116 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package faults
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestMiddleware(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e := NewEngine()
+	h := e.Middleware("/users", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+
+	do := func(method string, url string, clientID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Client-ID", clientID)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// no rules
+	w := do(http.MethodGet, "/mock/users", "client")
+	a.Equal(200, w.Code)
+	a.Equal(`{"data":[]}`, w.Body.String())
+
+	rule, err := e.Add(Rule{Method: "get", Path: "/users", Query: map[string]string{"login": "foo"}, ClientID: "client", Status: 503})
+	a.Nil(err)
+	a.NotEmpty(rule.ID)
+
+	w = do(http.MethodGet, "/mock/users?login=bar", "client")
+	a.Equal(200, w.Code)
+	w = do(http.MethodGet, "/mock/users?login=foo", "other")
+	a.Equal(200, w.Code)
+	w = do(http.MethodPost, "/mock/users?login=foo", "client")
+	a.Equal(200, w.Code)
+
+	w = do(http.MethodGet, "/mock/users?login=foo", "client")
+	a.Equal(503, w.Code)
+	var body mock_errors.ErrorMessage
+	a.Nil(json.Unmarshal(w.Body.Bytes(), &body))
+	a.Equal(503, body.StatusCode)
+	a.Equal("Service Unavailable", body.Error)
+
+	a.True(e.Remove(rule.ID))
+	a.False(e.Remove(rule.ID))
+
+	// corrupted bodies keep the original status but fail to parse
+	_, err = e.Add(Rule{Path: "/u*", CorruptBody: true})
+	a.Nil(err)
+	w = do(http.MethodGet, "/mock/users", "client")
+	a.Equal(200, w.Code)
+	a.NotNil(json.Unmarshal(w.Body.Bytes(), &body))
+
+	// probability
+	e.Clear()
+	_, err = e.Add(Rule{Status: 500, Probability: 0.5})
+	a.Nil(err)
+	e.roll = func() float64 { return 0.7 }
+	w = do(http.MethodGet, "/mock/users", "client")
+	a.Equal(200, w.Code)
+	e.roll = func() float64 { return 0.2 }
+	w = do(http.MethodGet, "/mock/users", "client")
+	a.Equal(500, w.Code)
+}
+
+func TestAdd(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	e := NewEngine()
+
+	_, err := e.Add(Rule{Path: "/users"})
+	a.NotNil(err)
+	_, err = e.Add(Rule{Status: 200})
+	a.NotNil(err)
+	_, err = e.Add(Rule{Status: 500, Probability: 2})
+	a.NotNil(err)
+	_, err = e.Add(Rule{Latency: "soon"})
+	a.NotNil(err)
+
+	_, err = e.Add(Rule{ID: "slow", Latency: "10ms", Path: "users"})
+	a.Nil(err)
+	_, err = e.Add(Rule{ID: "slow", Status: 500})
+	a.NotNil(err)
+
+	rules := e.Rules()
+	a.Len(rules, 1)
+	a.Equal("/users", rules[0].Path)
+}
+
+func TestLoadFile(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	path := filepath.Join(t.TempDir(), "faults.json")
+	err := os.WriteFile(path, []byte(`{"rules":[{"id":"a","path":"/users","status":500},{"id":"b","latency":"1s"}]}`), 0644)
+	a.Nil(err)
+
+	e := NewEngine()
+	a.Nil(e.LoadFile(path))
+	a.Len(e.Rules(), 2)
+
+	err = os.WriteFile(path, []byte(`{"rules":[{"id":"a"}]}`), 0644)
+	a.Nil(err)
+	a.NotNil(NewEngine().LoadFile(path))
+}
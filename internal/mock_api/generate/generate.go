@@ -46,6 +46,12 @@ func Generate(userCount int) error {
 	}
 	generateAuthorization(ctx, c, "")
 
+	// generate extensions and install them for the generated users
+	err = generateExtensions(ctx)
+	if err != nil {
+		return err
+	}
+
 	log.Print("Finished generation.")
 	return nil
 }
@@ -542,6 +548,78 @@ func generateClient(ctx context.Context) (database.AuthenticationClient, error)
 	return client, err
 }
 
+func generateExtensions(ctx context.Context) error {
+	db := ctx.Value("db").(database.CLIDatabase)
+
+	mockExtensions := []database.Extension{
+		{Name: "Mock Panel Extension", Types: "panel,mobile"},
+		{Name: "Mock Overlay Extension", Types: "overlay,mobile"},
+		{Name: "Mock Component Extension", Types: "component"},
+	}
+
+	log.Printf("Creating extensions...")
+	for i, e := range mockExtensions {
+		client := database.AuthenticationClient{
+			ID:          util.RandomClientID(),
+			Name:        e.Name,
+			IsExtension: true,
+		}
+
+		client, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(client, false)
+		if err != nil {
+			return err
+		}
+		log.Printf("Created Extension Client. Details:\nClient-ID: %v\nSecret: %v\nName: %v", client.ID, client.Secret, client.Name)
+
+		mockExtensions[i].ID = client.ID
+		mockExtensions[i].Version = "1.0.0"
+		mockExtensions[i].CanActivate = true
+		err = db.NewQuery(nil, 100).InsertExtension(mockExtensions[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	dbr, err := db.NewQuery(nil, 1000).GetUsers(database.User{})
+	if err != nil {
+		return err
+	}
+
+	// install every extension for every user, and activate each in its first slot for roughly half of them
+	for _, u := range dbr.Data.([]database.User) {
+		activate := []database.UserExtensionSlot{}
+		for _, e := range mockExtensions {
+			err := db.NewQuery(nil, 100).InstallUserExtension(u.ID, e.ID)
+			if err != nil {
+				log.Print(err.Error())
+				continue
+			}
+
+			if util.RandomInt(2) == 0 {
+				continue
+			}
+
+			slot := database.UserExtensionSlot{
+				SlotType:    e.GetTypes()[0],
+				Slot:        "1",
+				ExtensionID: e.ID,
+			}
+			if slot.SlotType == "component" {
+				slot.X = sql.NullInt64{Int64: 0, Valid: true}
+				slot.Y = sql.NullInt64{Int64: 0, Valid: true}
+			}
+			activate = append(activate, slot)
+		}
+
+		err := db.NewQuery(nil, 100).UpdateUserExtensionSlots(u.ID, activate, nil)
+		if err != nil {
+			log.Print(err.Error())
+		}
+	}
+
+	return nil
+}
+
 func generateAuthorization(ctx context.Context, c database.AuthenticationClient, userID string) error {
 	db := ctx.Value("db").(database.CLIDatabase)
 
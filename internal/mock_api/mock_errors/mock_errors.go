@@ -49,3 +49,7 @@ func WriteUnprocessableEntity(w http.ResponseWriter, message string) {
 	w.WriteHeader(http.StatusUnprocessableEntity)
 	w.Write(GetErrorBytes(http.StatusUnprocessableEntity, errors.New("Unprocessable Entity"), message))
 }
+func WriteTooManyRequests(w http.ResponseWriter, message string) {
+	w.WriteHeader(http.StatusTooManyRequests)
+	w.Write(GetErrorBytes(http.StatusTooManyRequests, errors.New("Too Many Requests"), message))
+}
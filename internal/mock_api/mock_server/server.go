@@ -18,8 +18,11 @@ import (
 	"github.com/twitchdev/twitch-cli/internal/database"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/faults"
 	"github.com/twitchdev/twitch-cli/internal/mock_api/generate"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/ratelimit"
 	"github.com/twitchdev/twitch-cli/internal/mock_auth"
+	"github.com/twitchdev/twitch-cli/internal/mock_files"
 	"github.com/twitchdev/twitch-cli/internal/mock_units"
 	"github.com/twitchdev/twitch-cli/internal/models"
 )
@@ -27,8 +30,17 @@ import (
 const MOCK_NAMESPACE = "/mock"
 const UNITS_NAMESPACE = "/units"
 const AUTH_NAMESPACE = "/auth"
+const MOCK_FILES_NAMESPACE = mock_files.Namespace
+
+// ServerOptions configures optional behavior of the mock server
+type ServerOptions struct {
+	// RateLimiter applies Helix-style rate limits to the /mock endpoints; nil disables rate limiting
+	RateLimiter *ratelimit.Limiter
+	// Faults injects errors, latency, and corrupted bodies into /mock endpoints; rules can also be managed at runtime via /units/faults
+	Faults *faults.Engine
+}
 
-func StartServer(port int) error {
+func StartServer(port int, opts ServerOptions) error {
 	m := http.NewServeMux()
 
 	ctx := context.Background()
@@ -49,7 +61,12 @@ func StartServer(port int) error {
 
 	ctx = context.WithValue(ctx, "db", db)
 
-	RegisterHandlers(m)
+	if opts.Faults == nil {
+		opts.Faults = faults.NewEngine()
+	}
+	ctx = context.WithValue(ctx, "faults", opts.Faults)
+
+	RegisterHandlers(m, opts)
 	s := http.Server{
 		Addr:    fmt.Sprintf(":%v", port),
 		Handler: m,
@@ -57,7 +74,7 @@ func StartServer(port int) error {
 			return ctx
 		},
 	}
-	stop := make(chan os.Signal)
+	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 
 	var serverErr error = nil
@@ -91,15 +108,22 @@ func StartServer(port int) error {
 	return nil
 }
 
-func RegisterHandlers(m *http.ServeMux) {
+func RegisterHandlers(m *http.ServeMux, opts ServerOptions) {
 	// all mock endpoints live in the /mock/ namespace
 	for _, e := range endpoints.All() {
-		// no auth requirements on this endpoint, so just add it manually
-		if e.Path() == "/schedule/icalendar" {
-			m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(e))
-			continue
+		var h http.Handler = e
+		// no auth requirements on the icalendar endpoint, so it skips authentication and rate limiting
+		if e.Path() != "/schedule/icalendar" {
+			if opts.RateLimiter != nil {
+				h = opts.RateLimiter.Handler(e)
+			} else {
+				h = authentication.AuthenticationMiddleware(e)
+			}
 		}
-		m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(authentication.AuthenticationMiddleware(e)))
+		if opts.Faults != nil {
+			h = opts.Faults.Middleware(e.Path(), h)
+		}
+		m.Handle(MOCK_NAMESPACE+e.Path(), loggerMiddleware(h))
 	}
 	for _, e := range mock_units.All() {
 		m.Handle(UNITS_NAMESPACE+e.Path(), loggerMiddleware(e))
@@ -109,6 +133,11 @@ func RegisterHandlers(m *http.ServeMux) {
 		m.Handle(AUTH_NAMESPACE+e.Path(), loggerMiddleware(e))
 	}
 
+	// generated files (e.g. analytics reports) linked to from mock endpoints; unauthenticated, like the production CDN links
+	for _, e := range mock_files.All() {
+		m.Handle(MOCK_FILES_NAMESPACE+e.Path(), loggerMiddleware(e))
+	}
+
 	// For removed endpoints we don't have to worry about an actual handler, since its just gonna return 410 Gone
 	for e := range endpoints.Gone() {
 		m.Handle(MOCK_NAMESPACE+e, loggerMiddleware(nil))
@@ -0,0 +1,288 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+)
+
+// DefaultLimit matches the production Helix bucket of 800 points per minute
+const DefaultLimit = 800
+
+var DefaultWindow = time.Minute
+
+// sweepInterval is how often buckets that have refilled are removed; a full bucket is the same as a new one, so removing them doesn't change any limits
+var sweepInterval = time.Minute
+
+// defaultEndpointBuckets are the endpoints that have their own limits in production, separate from the global bucket
+var defaultEndpointBuckets = map[string]BucketConfig{
+	"POST /clips":    {Limit: 600, Window: time.Minute},
+	"POST /whispers": {Limit: 100, Window: time.Minute},
+}
+
+type BucketConfig struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Config defines the default bucket, and any endpoint specific buckets keyed by "METHOD /path"
+type Config struct {
+	Default   BucketConfig
+	Endpoints map[string]BucketConfig
+}
+
+type fileBucketConfig struct {
+	Limit  int    `json:"limit"`
+	Window string `json:"window"`
+}
+
+type fileConfig struct {
+	Default   *fileBucketConfig           `json:"default"`
+	Endpoints map[string]fileBucketConfig `json:"endpoints"`
+}
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+	full       time.Time // when the bucket will be full again if no more points are taken
+}
+
+type Limiter struct {
+	config    Config
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// DefaultConfig returns the production-like configuration, with the default bucket set to the provided limit per minute
+func DefaultConfig(limit int) Config {
+	c := Config{
+		Default:   BucketConfig{Limit: limit, Window: DefaultWindow},
+		Endpoints: map[string]BucketConfig{},
+	}
+	for k, v := range defaultEndpointBuckets {
+		c.Endpoints[k] = v
+	}
+	return c
+}
+
+// LoadConfig reads a JSON configuration file and applies it on top of the provided configuration
+func LoadConfig(path string, c Config) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+
+	var fc fileConfig
+	if err := json.Unmarshal(b, &fc); err != nil {
+		return c, fmt.Errorf("Error parsing rate limit configuration: %v", err)
+	}
+
+	if fc.Default != nil {
+		bc, err := fc.Default.toBucketConfig()
+		if err != nil {
+			return c, fmt.Errorf("Invalid default rate limit: %v", err)
+		}
+		c.Default = bc
+	}
+
+	if c.Endpoints == nil {
+		c.Endpoints = map[string]BucketConfig{}
+	}
+	for k, v := range fc.Endpoints {
+		parts := strings.SplitN(k, " ", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[1], "/") {
+			return c, fmt.Errorf("Invalid rate limit endpoint %q; must be in the format of \"METHOD /path\"", k)
+		}
+
+		bc, err := v.toBucketConfig()
+		if err != nil {
+			return c, fmt.Errorf("Invalid rate limit for %v: %v", k, err)
+		}
+		c.Endpoints[strings.ToUpper(parts[0])+" "+parts[1]] = bc
+	}
+
+	return c, nil
+}
+
+func (f fileBucketConfig) toBucketConfig() (BucketConfig, error) {
+	window := DefaultWindow
+	if f.Window != "" {
+		w, err := time.ParseDuration(f.Window)
+		if err != nil {
+			return BucketConfig{}, err
+		}
+		window = w
+	}
+
+	if f.Limit <= 0 || window <= 0 {
+		return BucketConfig{}, errors.New("limit and window must be greater than 0")
+	}
+
+	return BucketConfig{Limit: f.Limit, Window: window}, nil
+}
+
+func NewLimiter(c Config) *Limiter {
+	return &Limiter{
+		config:  c,
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Middleware applies the bucket for the given endpoint path to each request. It must run after authentication, as buckets are
+// tracked per authenticated Client ID and user, which mirrors production where app tokens share a bucket per client and user
+// tokens have a bucket per client/user pair.
+func (l *Limiter) Middleware(path string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, bc := l.bucketFor(r.Method, path)
+
+		auth, ok := r.Context().Value("auth").(authentication.UserAuthentication)
+		if !ok {
+			mock_errors.WriteServerError(w, "Rate limiting requires an authenticated request")
+			return
+		}
+		allowed, remaining, reset := l.take(bucketKey(name, auth.ClientID, auth.UserID), bc)
+		setHeaders(w, bc, remaining, reset)
+
+		if !allowed {
+			mock_errors.WriteTooManyRequests(w, "Rate limit exceeded")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handler authenticates and rate limits an endpoint's requests. Every response has the Ratelimit headers: requests that
+// authentication rejects report the bucket of the Client-ID header, without taking a point from it.
+func (l *Limiter) Handler(e mock_api.MockEndpoint) http.Handler {
+	limited := authentication.AuthenticationMiddleware(l.Endpoint(e))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, bc := l.bucketFor(r.Method, e.Path())
+		remaining, reset := l.peek(bucketKey(name, r.Header.Get("Client-ID"), ""), bc)
+		setHeaders(w, bc, remaining, reset)
+
+		// authenticated requests replace the headers with those of their own bucket
+		limited.ServeHTTP(w, r)
+	})
+}
+
+// bucketFor returns the name and configuration of the bucket used by requests to the endpoint path with the method
+func (l *Limiter) bucketFor(method string, path string) (string, BucketConfig) {
+	if bc, ok := l.config.Endpoints[method+" "+path]; ok {
+		return method + " " + path, bc
+	}
+	return "default", l.config.Default
+}
+
+func bucketKey(name string, clientID string, userID string) string {
+	return fmt.Sprintf("%v|%v|%v", name, clientID, userID)
+}
+
+func setHeaders(w http.ResponseWriter, bc BucketConfig, remaining int, reset time.Time) {
+	w.Header().Set("Ratelimit-Limit", strconv.Itoa(bc.Limit))
+	w.Header().Set("Ratelimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+}
+
+// Endpoint wraps an endpoint so its requests are rate limited once authentication.AuthenticationMiddleware has authenticated them
+func (l *Limiter) Endpoint(e mock_api.MockEndpoint) mock_api.MockEndpoint {
+	return limitedEndpoint{MockEndpoint: e, handler: l.Middleware(e.Path(), e)}
+}
+
+type limitedEndpoint struct {
+	mock_api.MockEndpoint
+	handler http.Handler
+}
+
+func (e limitedEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.handler.ServeHTTP(w, r)
+}
+
+func (e limitedEndpoint) GetAcceptedTokenTypes(method string) []string {
+	if t, ok := e.MockEndpoint.(mock_api.TokenTypeEndpoint); ok {
+		return t.GetAcceptedTokenTypes(method)
+	}
+	return nil
+}
+
+// take removes a single point from the bucket, returning whether the request is allowed, the points remaining, and when the bucket will be full again
+func (l *Limiter) take(key string, bc BucketConfig) (bool, int, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		for k, b := range l.buckets {
+			if !now.Before(b.full) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(bc.Limit), lastRefill: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = refilled(b, bc, now)
+	b.lastRefill = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	b.full = fullAt(b.tokens, bc, now)
+	return allowed, int(math.Floor(b.tokens)), resetAt(b.tokens, bc, now)
+}
+
+// peek returns the points remaining in the bucket and when it will be full again, without taking a point or creating the bucket
+func (l *Limiter) peek(key string, bc BucketConfig) (int, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		return bc.Limit, now
+	}
+	tokens := refilled(b, bc, now)
+	return int(math.Floor(tokens)), resetAt(tokens, bc, now)
+}
+
+// refilled returns the points in the bucket at now, which refills continuously up to the limit
+func refilled(b *bucket, bc BucketConfig, now time.Time) float64 {
+	ratePerSecond := float64(bc.Limit) / bc.Window.Seconds()
+	return math.Min(float64(bc.Limit), b.tokens+now.Sub(b.lastRefill).Seconds()*ratePerSecond)
+}
+
+// fullAt returns when a bucket with the given points will be full again
+func fullAt(tokens float64, bc BucketConfig, now time.Time) time.Time {
+	secondsUntilFull := (float64(bc.Limit) - tokens) / (float64(bc.Limit) / bc.Window.Seconds())
+	return now.Add(time.Duration(secondsUntilFull * float64(time.Second)))
+}
+
+// resetAt is fullAt rounded up to the second, as reported in the Ratelimit-Reset header
+func resetAt(tokens float64, bc BucketConfig, now time.Time) time.Time {
+	secondsUntilFull := (float64(bc.Limit) - tokens) / (float64(bc.Limit) / bc.Window.Seconds())
+	return now.Add(time.Duration(math.Ceil(secondsUntilFull)) * time.Second)
+}
@@ -0,0 +1,173 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/authentication"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestMiddleware(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	c := DefaultConfig(2)
+	c.Endpoints["POST /clips"] = BucketConfig{Limit: 1, Window: time.Minute}
+	l := NewLimiter(c)
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	h := l.Middleware("/clips", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	do := func(method string, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/mock/clips", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "auth", authentication.UserAuthentication{ClientID: "client", UserID: userID}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "a")
+	a.Equal(200, w.Code)
+	a.Equal("2", w.Header().Get("Ratelimit-Limit"))
+	a.Equal("1", w.Header().Get("Ratelimit-Remaining"))
+	a.Equal(strconv.FormatInt(now.Add(30*time.Second).Unix(), 10), w.Header().Get("Ratelimit-Reset"))
+
+	w = do(http.MethodGet, "a")
+	a.Equal(200, w.Code)
+	a.Equal("0", w.Header().Get("Ratelimit-Remaining"))
+
+	w = do(http.MethodGet, "a")
+	a.Equal(429, w.Code)
+
+	// separate users get separate buckets
+	w = do(http.MethodGet, "b")
+	a.Equal(200, w.Code)
+
+	// app access tokens share the client's bucket
+	w = do(http.MethodGet, "")
+	a.Equal(200, w.Code)
+	w = do(http.MethodGet, "")
+	a.Equal(200, w.Code)
+	w = do(http.MethodGet, "")
+	a.Equal(429, w.Code)
+
+	// endpoint specific bucket
+	w = do(http.MethodPost, "a")
+	a.Equal(200, w.Code)
+	a.Equal("1", w.Header().Get("Ratelimit-Limit"))
+	w = do(http.MethodPost, "a")
+	a.Equal(429, w.Code)
+
+	// buckets refill over time
+	now = now.Add(30 * time.Second)
+	w = do(http.MethodGet, "a")
+	a.Equal(200, w.Code)
+	a.Equal("0", w.Header().Get("Ratelimit-Remaining"))
+
+	// buckets that have refilled are removed
+	now = now.Add(time.Minute)
+	w = do(http.MethodGet, "a")
+	a.Equal(200, w.Code)
+	a.Len(l.buckets, 1)
+
+	// unauthenticated requests are never counted
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mock/clips", nil))
+	a.Equal(500, w.Code)
+}
+
+type testEndpoint struct{}
+
+func (e testEndpoint) Path() string { return "/endpoint" }
+
+func (e testEndpoint) GetRequiredScopes(method string) []string { return []string{} }
+
+func (e testEndpoint) ValidMethod(method string) bool { return true }
+
+func (e testEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestHandler(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	db, err := database.NewConnection(false)
+	a.Nil(err)
+	defer db.DB.Close()
+
+	ac, err := db.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{ID: util.RandomClientID(), Secret: "1234", Name: "ratelimit_client"}, false)
+	a.Nil(err)
+	auth, err := db.NewQuery(nil, 100).CreateAuthorization(database.Authorization{ClientID: ac.ID, ExpiresAt: util.GetTimestamp().Add(time.Hour).Format(time.RFC3339)})
+	a.Nil(err)
+
+	l := NewLimiter(DefaultConfig(2))
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	h := l.Handler(testEndpoint{})
+
+	do := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/mock/endpoint", nil)
+		req = req.WithContext(context.WithValue(req.Context(), "db", db))
+		req.Header.Set("Client-ID", ac.ID)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// requests rejected by authentication still report the client's bucket, without using it
+	w := do("invalid")
+	a.Equal(401, w.Code)
+	a.Equal("2", w.Header().Get("Ratelimit-Limit"))
+	a.Equal("2", w.Header().Get("Ratelimit-Remaining"))
+	a.Equal(strconv.FormatInt(now.Unix(), 10), w.Header().Get("Ratelimit-Reset"))
+	a.Len(l.buckets, 0)
+
+	w = do(auth.Token)
+	a.Equal(200, w.Code)
+	a.Equal("1", w.Header().Get("Ratelimit-Remaining"))
+
+	w = do("invalid")
+	a.Equal(401, w.Code)
+	a.Equal("2", w.Header().Get("Ratelimit-Limit"))
+	a.Equal("1", w.Header().Get("Ratelimit-Remaining"))
+	a.Equal(strconv.FormatInt(now.Add(30*time.Second).Unix(), 10), w.Header().Get("Ratelimit-Reset"))
+}
+
+func TestLoadConfig(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	path := filepath.Join(t.TempDir(), "ratelimits.json")
+	err := os.WriteFile(path, []byte(`{"default":{"limit":10,"window":"10s"},"endpoints":{"post /whispers":{"limit":3,"window":"1s"}}}`), 0644)
+	a.Nil(err)
+
+	c, err := LoadConfig(path, DefaultConfig(DefaultLimit))
+	a.Nil(err)
+	a.Equal(BucketConfig{Limit: 10, Window: 10 * time.Second}, c.Default)
+	a.Equal(BucketConfig{Limit: 3, Window: time.Second}, c.Endpoints["POST /whispers"])
+	a.Equal(defaultEndpointBuckets["POST /clips"], c.Endpoints["POST /clips"])
+
+	err = os.WriteFile(path, []byte(`{"endpoints":{"/whispers":{"limit":3}}}`), 0644)
+	a.Nil(err)
+	_, err = LoadConfig(path, DefaultConfig(DefaultLimit))
+	a.NotNil(err)
+
+	err = os.WriteFile(path, []byte(`{"default":{"limit":0}}`), 0644)
+	a.Nil(err)
+	_, err = LoadConfig(path, DefaultConfig(DefaultLimit))
+	a.NotNil(err)
+}
@@ -0,0 +1,554 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+)
+
+const endpointsPackage = "github.com/twitchdev/twitch-cli/internal/mock_api/endpoints"
+
+const header = `// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+`
+
+const generatedHeader = header + `
+// Code generated by twitch mock-api scaffold; DO NOT EDIT.
+`
+
+// generatedSuffix marks the files the scaffold owns and rewrites every time it runs
+const generatedSuffix = "_gen.go"
+
+// Options describe where the scaffold writes mock endpoints and catalog entries
+type Options struct {
+	EndpointsDir string // the directory of the mock endpoint packages, e.g. internal/mock_api/endpoints
+	CatalogFile  string // the generated catalog file, e.g. internal/api/catalog_gen.go
+}
+
+// Result lists what the scaffold did to the mock endpoints
+type Result struct {
+	Generated []string // paths with generated mock endpoints
+	Created   []string // files created for generated endpoints' ServeHTTP stubs
+	Skipped   []string // paths that weren't generated, with the reason
+}
+
+// Generate writes mock endpoints and catalog entries for every path.
+// Paths with a hand-written mock endpoint are skipped, and files other than *_gen.go files are only created, never overwritten.
+func Generate(paths []Path, o Options) (Result, error) {
+	result := Result{}
+
+	handWritten, declared, err := scanEndpoints(o.EndpointsDir)
+	if err != nil {
+		return result, err
+	}
+
+	registered := []endpoint{}
+	for _, p := range paths {
+		e := newEndpoint(p)
+		dir := filepath.Join(o.EndpointsDir, e.pkg)
+
+		skipped := ""
+		if handWritten[p.Path] {
+			skipped = p.Path + ": hand-written mock endpoint"
+		} else if conflict := e.conflict(declared[e.pkg]); conflict != "" {
+			skipped = fmt.Sprintf("%v: %v is already declared in package %v", p.Path, conflict, e.pkg)
+		}
+		if skipped != "" {
+			// an endpoint that's been implemented by hand no longer needs its generated file
+			if err := removeGenerated(filepath.Join(dir, e.file+generatedSuffix)); err != nil {
+				return result, err
+			}
+			result.Skipped = append(result.Skipped, skipped)
+			continue
+		}
+
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return result, err
+		}
+		if err := writeSource(filepath.Join(dir, e.file+generatedSuffix), e.generated()); err != nil {
+			return result, err
+		}
+
+		stub := filepath.Join(dir, e.file+".go")
+		if _, err := os.Stat(stub); os.IsNotExist(err) {
+			if err := writeSource(stub, e.stub()); err != nil {
+				return result, err
+			}
+			result.Created = append(result.Created, stub)
+		}
+
+		registered = append(registered, e)
+		result.Generated = append(result.Generated, p.Path)
+	}
+
+	if err := writeSource(filepath.Join(o.EndpointsDir, "endpoints"+generatedSuffix), registration(registered)); err != nil {
+		return result, err
+	}
+	if o.CatalogFile != "" {
+		if err := writeSource(o.CatalogFile, catalog(paths)); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// scanEndpoints finds the paths served by hand-written mock endpoints, and the names declared by hand-written files in each package
+func scanEndpoints(dir string) (map[string]bool, map[string]map[string]bool, error) {
+	handWritten := map[string]bool{}
+	declared := map[string]map[string]bool{}
+
+	fset := token.NewFileSet()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && strings.HasPrefix(info.Name(), "_") {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, generatedSuffix) || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		pkg := filepath.Base(filepath.Dir(path))
+		if declared[pkg] == nil {
+			declared[pkg] = map[string]bool{}
+		}
+
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					declared[pkg][d.Name.Name] = true
+				} else if p, ok := returnedString(d); ok && d.Name.Name == "Path" {
+					handWritten[p] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						declared[pkg][s.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							declared[pkg][n.Name] = true
+						}
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return handWritten, declared, nil
+	}
+	return handWritten, declared, err
+}
+
+// returnedString returns the string a function returns, for methods like `func (e Endpoint) Path() string { return "/users" }`
+func returnedString(f *ast.FuncDecl) (string, bool) {
+	if f.Body == nil || len(f.Body.List) != 1 {
+		return "", false
+	}
+	r, ok := f.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(r.Results) != 1 {
+		return "", false
+	}
+	lit, ok := r.Results[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// endpoint is the Go names a path is generated with: /guest_star/channel_settings is the type ChannelSettings in package guest_star
+type endpoint struct {
+	Path
+	pkg      string
+	typeName string
+	prefix   string // of the package level variables, e.g. channelSettings
+	file     string
+}
+
+func newEndpoint(p Path) endpoint {
+	segments := []string{}
+	for _, s := range strings.Split(strings.Trim(p.Path, "/"), "/") {
+		s = strings.ToLower(strings.NewReplacer("-", "_", "{", "", "}", "").Replace(s))
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	name := segments
+	if len(segments) > 1 {
+		name = segments[1:]
+	}
+	words := strings.Split(strings.Join(name, "_"), "_")
+	return endpoint{
+		Path:     p,
+		pkg:      segments[0],
+		typeName: camel(words, true),
+		prefix:   camel(words, false),
+		file:     strings.Join(name, "_"),
+	}
+}
+
+func (e endpoint) names() []string {
+	names := []string{e.typeName, e.prefix + "MethodsSupported", e.prefix + "ScopesByMethod", e.prefix + "TokenTypesByMethod"}
+	for _, o := range e.Operations {
+		if len(o.Body) > 0 {
+			names = append(names, e.requestType(o))
+		}
+		if len(o.Response) > 0 {
+			names = append(names, e.responseType(o))
+		}
+	}
+	return names
+}
+
+// conflict returns a name the endpoint would declare that a hand-written file already declares
+func (e endpoint) conflict(declared map[string]bool) string {
+	for _, n := range e.names() {
+		if declared[n] {
+			return n
+		}
+	}
+	return ""
+}
+
+func (e endpoint) requestType(o Operation) string {
+	return camel([]string{strings.ToLower(o.Method)}, true) + e.typeName + "RequestBody"
+}
+
+func (e endpoint) responseType(o Operation) string {
+	return camel([]string{strings.ToLower(o.Method)}, true) + e.typeName + "ResponseBody"
+}
+
+func (e endpoint) operation(method string) (Operation, bool) {
+	for _, o := range e.Operations {
+		if o.Method == method {
+			return o, true
+		}
+	}
+	return Operation{}, false
+}
+
+// generated is the *_gen.go file of an endpoint: everything but its ServeHTTP method
+func (e endpoint) generated() string {
+	var b strings.Builder
+	usesScopes := false
+	for _, o := range e.Operations {
+		usesScopes = usesScopes || len(o.TokenTypes) > 0 || len(o.Scopes) > 0
+	}
+
+	fmt.Fprintf(&b, "%v\npackage %v\n\nimport (\n\t\"net/http\"\n", generatedHeader, e.pkg)
+	if usesScopes {
+		fmt.Fprintf(&b, "\n\t\"github.com/twitchdev/twitch-cli/internal/scopes\"\n")
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "var %vMethodsSupported = map[string]bool{\n", e.prefix)
+	for _, m := range methods {
+		_, ok := e.operation(m)
+		fmt.Fprintf(&b, "http.Method%v: %v,\n", camel([]string{strings.ToLower(m)}, true), ok)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "var %vScopesByMethod = map[string][]string{\n", e.prefix)
+	for _, m := range methods {
+		o, _ := e.operation(m)
+		fmt.Fprintf(&b, "http.Method%v: {%v},\n", camel([]string{strings.ToLower(m)}, true), scopeNames(o.Scopes))
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(&b, "var %vTokenTypesByMethod = map[string][]string{\n", e.prefix)
+	for _, o := range e.Operations {
+		if len(o.TokenTypes) > 0 {
+			fmt.Fprintf(&b, "http.Method%v: {%v},\n", camel([]string{strings.ToLower(o.Method)}, true), tokenTypes(o.TokenTypes))
+		}
+	}
+	b.WriteString("}\n\n")
+
+	for _, o := range e.Operations {
+		if len(o.Body) > 0 {
+			writeStruct(&b, e.requestType(o), o.Body)
+		}
+		if len(o.Response) > 0 {
+			writeStruct(&b, e.responseType(o), o.Response)
+		}
+	}
+
+	fmt.Fprintf(&b, "type %v struct{}\n\n", e.typeName)
+	fmt.Fprintf(&b, "func (e %v) Path() string { return %q }\n\n", e.typeName, e.Path.Path)
+	fmt.Fprintf(&b, "func (e %v) GetRequiredScopes(method string) []string {\nreturn %vScopesByMethod[method]\n}\n\n", e.typeName, e.prefix)
+	fmt.Fprintf(&b, "func (e %v) GetAcceptedTokenTypes(method string) []string {\nreturn %vTokenTypesByMethod[method]\n}\n\n", e.typeName, e.prefix)
+	fmt.Fprintf(&b, "func (e %v) ValidMethod(method string) bool {\nreturn %vMethodsSupported[method]\n}\n", e.typeName, e.prefix)
+	return b.String()
+}
+
+// stub is the hand-written file of an endpoint, which is only created if it doesn't exist
+func (e endpoint) stub() string {
+	var b strings.Builder
+	responds := false
+	for _, o := range e.Operations {
+		responds = responds || len(o.Response) > 0
+	}
+
+	fmt.Fprintf(&b, "%vpackage %v\n\nimport (\n", header, e.pkg)
+	if responds {
+		b.WriteString("\"encoding/json\"\n")
+	}
+	b.WriteString("\"net/http\"\n")
+	if responds {
+		b.WriteString("\n\"github.com/twitchdev/twitch-cli/internal/models\"\n")
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(&b, "// ServeHTTP was scaffolded from an OpenAPI description; it responds with empty data until it's implemented\n")
+	fmt.Fprintf(&b, "func (e %v) ServeHTTP(w http.ResponseWriter, r *http.Request) {\nswitch r.Method {\n", e.typeName)
+	for _, o := range e.Operations {
+		fmt.Fprintf(&b, "case http.Method%v:\n", camel([]string{strings.ToLower(o.Method)}, true))
+		if len(o.Response) > 0 {
+			fmt.Fprintf(&b, "bytes, _ := json.Marshal(models.APIResponse{Data: []%v{}})\nw.Write(bytes)\n", e.responseType(o))
+		} else {
+			b.WriteString("w.WriteHeader(http.StatusNoContent)\n")
+		}
+	}
+	b.WriteString("default:\nw.WriteHeader(http.StatusMethodNotAllowed)\n}\n}\n")
+	return b.String()
+}
+
+func writeStruct(b *strings.Builder, name string, properties []Property) {
+	fmt.Fprintf(b, "type %v struct {\n", name)
+	for _, p := range properties {
+		fmt.Fprintf(b, "%v %v `json:\"%v\"`\n", camel(strings.Split(p.Name, "_"), true), goType(p.Type, p.ItemType), p.Name)
+	}
+	b.WriteString("}\n\n")
+}
+
+// registration is endpoints_gen.go, which lists the generated endpoints for endpoints.All
+func registration(endpoints []endpoint) string {
+	var b strings.Builder
+	pkgs := map[string]bool{}
+	for _, e := range endpoints {
+		pkgs[e.pkg] = true
+	}
+	imports := []string{}
+	for p := range pkgs {
+		imports = append(imports, p)
+	}
+	sort.Strings(imports)
+
+	fmt.Fprintf(&b, "%v\npackage endpoints\n\nimport (\n\"github.com/twitchdev/twitch-cli/internal/mock_api\"\n", generatedHeader)
+	for _, p := range imports {
+		fmt.Fprintf(&b, "%q\n", endpointsPackage+"/"+p)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// Generated returns the endpoints scaffolded from an OpenAPI description that don't have a hand-written implementation\n")
+	b.WriteString("func Generated() []mock_api.MockEndpoint {\nreturn []mock_api.MockEndpoint{")
+	if len(endpoints) > 0 {
+		b.WriteString("\n")
+	}
+	for _, e := range endpoints {
+		fmt.Fprintf(&b, "%v.%v{},\n", e.pkg, e.typeName)
+	}
+	b.WriteString("}\n}\n")
+	return b.String()
+}
+
+// catalog is catalog_gen.go, which has a catalog entry for every path
+func catalog(paths []Path) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v\npackage api\n\nvar generatedCatalog = []Endpoint{", generatedHeader)
+	if len(paths) > 0 {
+		b.WriteString("\n")
+	}
+	for _, p := range paths {
+		fmt.Fprintf(&b, "{\nPath: %q,\n", p.Path)
+		deprecated := len(p.Operations) > 0
+		for _, o := range p.Operations {
+			deprecated = deprecated && o.Deprecated
+		}
+		if deprecated {
+			b.WriteString("Deprecated: true,\n")
+		}
+
+		b.WriteString("Methods: map[string]EndpointMethod{\n")
+		for _, o := range p.Operations {
+			fmt.Fprintf(&b, "%q: {\nDescription: %q,\n", o.Method, o.Description)
+			if len(o.Parameters) > 0 {
+				b.WriteString("Parameters: []Parameter{\n")
+				for _, param := range o.Parameters {
+					fmt.Fprintf(&b, "{Name: %q, Type: %v, Description: %q", param.Name, typeConstant(param.Type), param.Description)
+					if param.Required {
+						b.WriteString(", Required: true")
+					}
+					if param.Repeatable {
+						b.WriteString(", Repeatable: true")
+					}
+					if param.MaxCount > 0 {
+						fmt.Fprintf(&b, ", MaxCount: %v", param.MaxCount)
+					}
+					b.WriteString("},\n")
+				}
+				b.WriteString("},\n")
+			}
+			if len(o.Body) > 0 {
+				b.WriteString("Body: []BodyField{\n")
+				for _, f := range o.Body {
+					fmt.Fprintf(&b, "{Name: %q, Type: %v, Description: %q", f.Name, typeConstant(f.Type), f.Description)
+					if f.Required {
+						b.WriteString(", Required: true")
+					}
+					b.WriteString("},\n")
+				}
+				b.WriteString("},\n")
+			}
+			if len(o.Scopes) > 0 {
+				fmt.Fprintf(&b, "Scopes: []string{%v},\n", quoted(o.Scopes))
+			}
+			if len(o.TokenTypes) > 0 {
+				fmt.Fprintf(&b, "TokenTypes: []string{%v},\n", quoted(o.TokenTypes))
+			}
+			if o.Paginated {
+				b.WriteString("Paginated: true,\n")
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n},\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// removeGenerated removes a file if it was written by the scaffold
+func removeGenerated(filename string) error {
+	b, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !strings.Contains(string(b), "// Code generated by twitch mock-api scaffold") {
+		return nil
+	}
+	return os.Remove(filename)
+}
+
+func writeSource(filename string, source string) error {
+	b, err := format.Source([]byte(source))
+	if err != nil {
+		return fmt.Errorf("Error formatting %v: %v", filename, err)
+	}
+	return os.WriteFile(filename, b, 0644)
+}
+
+// initialisms are the words written in capitals in Go names
+var initialisms = map[string]string{"id": "ID", "ids": "IDs", "url": "URL", "api": "API", "jwt": "JWT", "igdb": "IGDB", "json": "JSON"}
+
+// camel joins words into a Go name, e.g. ["broadcaster", "id"] into BroadcasterID
+func camel(words []string, exported bool) string {
+	var b strings.Builder
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		if i == 0 && !exported {
+			b.WriteString(strings.ToLower(w))
+		} else if initialism, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(initialism)
+		} else {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+func goType(t string, itemType string) string {
+	switch t {
+	case api.TypeString:
+		return "string"
+	case api.TypeInteger:
+		return "int"
+	case api.TypeNumber:
+		return "float64"
+	case api.TypeBoolean:
+		return "bool"
+	case api.TypeArray:
+		return "[]" + goType(itemType, "")
+	case api.TypeObject:
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+func typeConstant(t string) string {
+	switch t {
+	case api.TypeString:
+		return "TypeString"
+	case api.TypeInteger:
+		return "TypeInteger"
+	case api.TypeNumber:
+		return "TypeNumber"
+	case api.TypeBoolean:
+		return "TypeBoolean"
+	case api.TypeArray:
+		return "TypeArray"
+	case api.TypeObject:
+		return "TypeObject"
+	}
+	return strconv.Quote(t)
+}
+
+func quoted(values []string) string {
+	q := []string{}
+	for _, v := range values {
+		q = append(q, strconv.Quote(v))
+	}
+	return strings.Join(q, ", ")
+}
+
+// scopeNames writes scopes as the constants in the scopes package, quoting any the registry doesn't know
+func scopeNames(values []string) string {
+	names := []string{}
+	for _, v := range values {
+		if _, ok := scopes.Get(v); ok {
+			names = append(names, "scopes."+scopes.Identifier(v))
+		} else {
+			names = append(names, strconv.Quote(v))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// tokenTypes writes token types as the constants in the scopes package
+func tokenTypes(values []string) string {
+	names := []string{}
+	for _, v := range values {
+		switch v {
+		case scopes.AppAccessToken:
+			names = append(names, "scopes.AppAccessToken")
+		case scopes.UserAccessToken:
+			names = append(names, "scopes.UserAccessToken")
+		default:
+			names = append(names, strconv.Quote(v))
+		}
+	}
+	return strings.Join(names, ", ")
+}
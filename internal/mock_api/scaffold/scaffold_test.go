@@ -0,0 +1,222 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+var openAPI = `
+openapi: 3.0.0
+info: {title: Helix, version: "1"}
+components:
+  parameters:
+    BroadcasterID:
+      name: broadcaster_id
+      in: query
+      required: true
+      description: The ID of the broadcaster. Must match the user in the token.
+      schema: {type: string}
+  schemas:
+    Session:
+      type: object
+      properties:
+        id: {type: string}
+        slot_count: {type: integer}
+        guests: {type: array, items: {type: object}}
+paths:
+  /helix/guest_star/session:
+    get:
+      summary: Gets the active Guest Star session.
+      parameters:
+        - $ref: '#/components/parameters/BroadcasterID'
+        - {name: after, in: query, schema: {type: string}}
+      security:
+        - twitch_auth: [channel:read:guest_star]
+        - twitch_auth: [channel:manage:guest_star]
+      responses:
+        200:
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data: {type: array, items: {$ref: '#/components/schemas/Session'}}
+    post:
+      summary: Starts a Guest Star session.
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [slot_count]
+              properties:
+                slot_count: {type: integer}
+      security:
+        - twitch_auth: [channel:manage:guest_star]
+      responses:
+        "204": {description: ok}
+  /users:
+    get:
+      summary: Gets users.
+      x-token-types: [app, user]
+      parameters:
+        - {name: id, in: query, schema: {type: array, maxItems: 100, items: {type: string}}}
+      responses:
+        "200": {description: ok}
+`
+
+var swagger = `{
+  "swagger": "2.0",
+  "paths": {
+    "/extensions/jwt/secrets": {
+      "post": {
+        "summary": "Creates a JWT signing secret for an extension.",
+        "x-token-types": ["app"],
+        "parameters": [
+          {"name": "extension_id", "in": "query", "required": true, "type": "string"},
+          {"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Secret"}}
+        ],
+        "responses": {"200": {"schema": {"type": "object", "properties": {"data": {"type": "array", "items": {"type": "object", "properties": {"format_version": {"type": "integer"}}}}}}}}
+      }
+    }
+  },
+  "definitions": {
+    "Secret": {"type": "object", "properties": {"delay": {"type": "integer"}}}
+  }
+}`
+
+func TestParseSpec(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	paths, err := ParseSpec([]byte(openAPI))
+	a.Nil(err)
+	a.Len(paths, 2)
+
+	session := paths[0]
+	a.Equal("/guest_star/session", session.Path)
+	a.Len(session.Operations, 2)
+
+	get := session.Operations[0]
+	a.Equal("GET", get.Method)
+	a.Equal("Gets the active Guest Star session.", get.Description)
+	a.Equal(api.Parameter{Name: "broadcaster_id", Type: api.TypeString, Description: "The ID of the broadcaster.", Required: true}, get.Parameters[0])
+	a.True(get.Paginated)
+	a.Equal([]string{"channel:read:guest_star", "channel:manage:guest_star"}, get.Scopes)
+	a.Equal([]string{scopes.UserAccessToken}, get.TokenTypes)
+	a.Equal([]Property{
+		{Name: "guests", Type: api.TypeArray, ItemType: api.TypeObject},
+		{Name: "id", Type: api.TypeString},
+		{Name: "slot_count", Type: api.TypeInteger},
+	}, get.Response)
+
+	post := session.Operations[1]
+	a.Equal("POST", post.Method)
+	a.Equal([]Property{{Name: "slot_count", Type: api.TypeInteger, Required: true}}, post.Body)
+	a.Empty(post.Response)
+
+	users := paths[1].Operations[0]
+	a.Equal(api.Parameter{Name: "id", Type: api.TypeString, Repeatable: true, MaxCount: 100}, users.Parameters[0])
+	a.Empty(users.TokenTypes)
+
+	paths, err = ParseSpec([]byte(swagger))
+	a.Nil(err)
+	a.Len(paths, 1)
+	secrets := paths[0].Operations[0]
+	a.Equal([]string{scopes.AppAccessToken}, secrets.TokenTypes)
+	a.Equal([]Property{{Name: "delay", Type: api.TypeInteger}}, secrets.Body)
+	a.Equal([]Property{{Name: "format_version", Type: api.TypeInteger}}, secrets.Response)
+
+	_, err = ParseSpec([]byte(`{"paths": {}}`))
+	a.NotNil(err)
+}
+
+func TestGenerate(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	dir := t.TempDir()
+	endpointsDir := filepath.Join(dir, "endpoints")
+	catalogFile := filepath.Join(dir, "catalog_gen.go")
+
+	// a hand-written endpoint, which is left alone
+	a.Nil(os.MkdirAll(filepath.Join(endpointsDir, "users"), 0755))
+	users := []byte("package users\n\ntype UsersEndpoint struct{}\n\nfunc (e UsersEndpoint) Path() string { return \"/users\" }\n")
+	a.Nil(os.WriteFile(filepath.Join(endpointsDir, "users", "users.go"), users, 0644))
+
+	paths, err := ParseSpec([]byte(openAPI))
+	a.Nil(err)
+	result, err := Generate(paths, Options{EndpointsDir: endpointsDir, CatalogFile: catalogFile})
+	a.Nil(err)
+	a.Equal([]string{"/guest_star/session"}, result.Generated)
+	a.Equal([]string{"/users: hand-written mock endpoint"}, result.Skipped)
+	a.Len(result.Created, 1)
+
+	b, err := os.ReadFile(filepath.Join(endpointsDir, "users", "users.go"))
+	a.Nil(err)
+	a.Equal(users, b)
+
+	fset := token.NewFileSet()
+	for _, f := range []string{"guest_star/session_gen.go", "guest_star/session.go", "endpoints_gen.go"} {
+		_, err := parser.ParseFile(fset, filepath.Join(endpointsDir, f), nil, 0)
+		a.Nil(err, f)
+	}
+
+	b, err = os.ReadFile(filepath.Join(endpointsDir, "guest_star", "session_gen.go"))
+	a.Nil(err)
+	a.Contains(string(b), "DO NOT EDIT")
+	a.Contains(string(b), "type Session struct{}")
+	a.Contains(string(b), `func (e Session) Path() string { return "/guest_star/session" }`)
+	a.Regexp(`http.MethodGet:\s+\{scopes.ChannelReadGuestStar, scopes.ChannelManageGuestStar\}`, string(b))
+	a.Regexp(`http.MethodPost:\s+\{scopes.UserAccessToken\}`, string(b))
+	a.Regexp(`SlotCount\s+int\s+`+"`json:\"slot_count\"`", string(b))
+	a.Contains(string(b), "type PostSessionRequestBody struct")
+
+	b, err = os.ReadFile(filepath.Join(endpointsDir, "endpoints_gen.go"))
+	a.Nil(err)
+	a.Contains(string(b), `"github.com/twitchdev/twitch-cli/internal/mock_api/endpoints/guest_star"`)
+	a.Contains(string(b), "guest_star.Session{},")
+
+	b, err = os.ReadFile(catalogFile)
+	a.Nil(err)
+	_, err = parser.ParseFile(fset, catalogFile, b, 0)
+	a.Nil(err)
+	a.Contains(string(b), `Path: "/users"`)
+	a.Contains(string(b), `{Name: "broadcaster_id", Type: TypeString, Description: "The ID of the broadcaster.", Required: true}`)
+
+	// stubs are kept when regenerating, so the endpoint can be implemented by hand
+	stub := filepath.Join(endpointsDir, "guest_star", "session.go")
+	a.Nil(os.WriteFile(stub, []byte("package guest_star\n\n// implemented\n"), 0644))
+	result, err = Generate(paths, Options{EndpointsDir: endpointsDir, CatalogFile: catalogFile})
+	a.Nil(err)
+	a.Equal([]string{"/guest_star/session"}, result.Generated)
+	a.Empty(result.Created)
+	b, err = os.ReadFile(stub)
+	a.Nil(err)
+	a.Contains(string(b), "// implemented")
+
+	// names declared by hand-written files aren't generated again
+	a.Nil(os.WriteFile(stub, []byte("package guest_star\n\ntype GetSessionResponseBody struct{}\n"), 0644))
+	result, err = Generate(paths, Options{EndpointsDir: endpointsDir})
+	a.Nil(err)
+	a.Empty(result.Generated)
+	a.Equal("/guest_star/session: GetSessionResponseBody is already declared in package guest_star", result.Skipped[0])
+	_, err = os.Stat(filepath.Join(endpointsDir, "guest_star", "session_gen.go"))
+	a.True(os.IsNotExist(err))
+}
+
+func TestCamel(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	a.Equal("BroadcasterID", camel([]string{"broadcaster", "id"}, true))
+	a.Equal("jwtSecrets", camel([]string{"jwt", "secrets"}, false))
+	a.Equal("JWTSecrets", camel([]string{"jwt", "secrets"}, true))
+}
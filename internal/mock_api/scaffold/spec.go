@@ -0,0 +1,362 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package scaffold
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/twitchdev/twitch-cli/internal/api"
+	"github.com/twitchdev/twitch-cli/internal/scopes"
+	"gopkg.in/yaml.v3"
+)
+
+// methods are the methods scaffolded, in the order used by the mock endpoints
+var methods = []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPatch, http.MethodPut}
+
+// Path is a path in an OpenAPI description and the operations on it
+type Path struct {
+	Path       string
+	Operations []Operation // in the order of methods
+}
+
+// Operation is one method of a path
+type Operation struct {
+	Method      string
+	Description string
+	Deprecated  bool
+	Parameters  []api.Parameter // query parameters
+	Body        []Property      // fields of the JSON body
+	Response    []Property      // fields of each item in the response's data
+	Scopes      []string        // one of these scopes is required
+	TokenTypes  []string        // either token type is accepted if empty
+	Paginated   bool
+}
+
+// Property is a field of a request body or response
+type Property struct {
+	Name        string
+	Type        string
+	ItemType    string // the type of array items
+	Description string
+	Required    bool
+}
+
+// LoadSpec reads an OpenAPI 3 or Swagger 2 description of Helix, in YAML or JSON
+func LoadSpec(filename string) ([]Path, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpec(b)
+}
+
+// ParseSpec parses an OpenAPI 3 or Swagger 2 description of Helix, in YAML or JSON
+func ParseSpec(b []byte) ([]Path, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("Error parsing OpenAPI description: %v", err)
+	}
+	root, ok := normalize(doc).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Error parsing OpenAPI description: expected an object")
+	}
+	if root["openapi"] == nil && root["swagger"] == nil {
+		return nil, fmt.Errorf("Error parsing OpenAPI description: missing openapi or swagger version")
+	}
+	s := spec{root: root}
+
+	items, _ := root["paths"].(map[string]interface{})
+	names := []string{}
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	paths := []Path{}
+	for _, name := range names {
+		item := s.resolve(items[name])
+		p := Path{Path: "/" + strings.Trim(strings.TrimPrefix(name, "/helix"), "/")}
+
+		for _, method := range methods {
+			op := s.resolve(item[strings.ToLower(method)])
+			if op == nil {
+				continue
+			}
+			o, err := s.operation(method, item, op)
+			if err != nil {
+				return nil, fmt.Errorf("Error parsing %v %v: %v", method, name, err)
+			}
+			p.Operations = append(p.Operations, o)
+		}
+		if len(p.Operations) > 0 {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+type spec struct {
+	root map[string]interface{}
+}
+
+// resolve returns an object, following $refs within the document
+func (s spec) resolve(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	for i := 0; m != nil && i < 32; i++ {
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m
+		}
+		m = s.lookup(ref)
+	}
+	return m
+}
+
+func (s spec) lookup(ref string) map[string]interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var v interface{} = s.root
+	for _, part := range strings.Split(ref[2:], "/") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[strings.NewReplacer("~1", "/", "~0", "~").Replace(part)]
+	}
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func (s spec) operation(method string, item map[string]interface{}, op map[string]interface{}) (Operation, error) {
+	o := Operation{Method: method}
+	o.Description = summarize(str(op["summary"]))
+	if o.Description == "" {
+		o.Description = summarize(str(op["description"]))
+	}
+	o.Deprecated, _ = op["deprecated"].(bool)
+
+	params := append(list(item["parameters"]), list(op["parameters"])...)
+	for _, raw := range params {
+		p := s.resolve(raw)
+		switch str(p["in"]) {
+		case "query":
+			o.Parameters = append(o.Parameters, s.parameter(p))
+			if str(p["name"]) == "after" {
+				o.Paginated = true
+			}
+		case "body":
+			// Swagger 2 bodies are a parameter
+			o.Body = s.properties(s.resolve(p["schema"]))
+		}
+	}
+
+	if body := s.resolve(op["requestBody"]); body != nil {
+		o.Body = s.properties(s.jsonSchema(body))
+	}
+
+	responses := s.resolve(op["responses"])
+	for _, status := range []string{"200", "201", "202"} {
+		response := s.resolve(responses[status])
+		if response == nil {
+			continue
+		}
+		schema := s.jsonSchema(response)
+		if schema == nil {
+			schema = s.resolve(response["schema"])
+		}
+		o.Response = s.dataProperties(schema)
+		break
+	}
+
+	security := op["security"]
+	if security == nil {
+		security = s.root["security"]
+	}
+	seen := map[string]bool{}
+	for _, requirement := range list(security) {
+		r, _ := requirement.(map[string]interface{})
+		schemes := []string{}
+		for scheme := range r {
+			schemes = append(schemes, scheme)
+		}
+		sort.Strings(schemes)
+		for _, scheme := range schemes {
+			for _, scope := range list(r[scheme]) {
+				if !seen[str(scope)] {
+					seen[str(scope)] = true
+					o.Scopes = append(o.Scopes, str(scope))
+				}
+			}
+		}
+	}
+
+	for _, t := range list(op["x-token-types"]) {
+		switch str(t) {
+		case "app", scopes.AppAccessToken:
+			o.TokenTypes = append(o.TokenTypes, scopes.AppAccessToken)
+		case "user", scopes.UserAccessToken:
+			o.TokenTypes = append(o.TokenTypes, scopes.UserAccessToken)
+		default:
+			return o, fmt.Errorf("unknown token type %v in x-token-types", t)
+		}
+	}
+	// scopes are only granted to user access tokens
+	if op["x-token-types"] == nil && len(o.Scopes) > 0 {
+		o.TokenTypes = []string{scopes.UserAccessToken}
+	}
+	if len(o.TokenTypes) == 2 {
+		o.TokenTypes = nil
+	}
+	return o, nil
+}
+
+func (s spec) parameter(p map[string]interface{}) api.Parameter {
+	// OpenAPI 3 parameters describe their type with a schema, Swagger 2 parameters describe it directly
+	schema := s.resolve(p["schema"])
+	if schema == nil {
+		schema = p
+	}
+
+	param := api.Parameter{
+		Name:        str(p["name"]),
+		Type:        schemaType(schema),
+		Description: summarize(str(p["description"])),
+	}
+	param.Required, _ = p["required"].(bool)
+	if param.Type == api.TypeArray {
+		param.Type = schemaType(s.resolve(schema["items"]))
+		param.Repeatable = true
+		param.MaxCount = integer(schema["maxItems"])
+	}
+	return param
+}
+
+// jsonSchema returns the schema of an OpenAPI 3 request body or response's JSON content
+func (s spec) jsonSchema(v map[string]interface{}) map[string]interface{} {
+	content, _ := v["content"].(map[string]interface{})
+	media := s.resolve(content["application/json"])
+	return s.resolve(media["schema"])
+}
+
+// dataProperties returns the properties of the items in a response's data, which is usually an array
+func (s spec) dataProperties(schema map[string]interface{}) []Property {
+	props, _ := schema["properties"].(map[string]interface{})
+	data := s.resolve(props["data"])
+	if data == nil {
+		return nil
+	}
+	if schemaType(data) == api.TypeArray {
+		data = s.resolve(data["items"])
+	}
+	return s.properties(data)
+}
+
+func (s spec) properties(schema map[string]interface{}) []Property {
+	if schema == nil {
+		return nil
+	}
+	required := map[string]bool{}
+	for _, r := range list(schema["required"]) {
+		required[str(r)] = true
+	}
+
+	props, _ := schema["properties"].(map[string]interface{})
+	names := []string{}
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties := []Property{}
+	for _, name := range names {
+		p := s.resolve(props[name])
+		property := Property{
+			Name:        name,
+			Type:        schemaType(p),
+			Description: summarize(str(p["description"])),
+			Required:    required[name],
+		}
+		if property.Type == api.TypeArray {
+			property.ItemType = schemaType(s.resolve(p["items"]))
+		}
+		properties = append(properties, property)
+	}
+	return properties
+}
+
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		// OpenAPI 3.1 types can be a list, such as ["string", "null"]
+		for _, v := range t {
+			if str(v) != "null" {
+				return str(v)
+			}
+		}
+	}
+	if schema["properties"] != nil {
+		return api.TypeObject
+	}
+	return api.TypeString
+}
+
+// summarize returns the first sentence of a description
+func summarize(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i+1]
+	}
+	return s
+}
+
+// normalize converts the maps with non-string keys yaml.v3 decodes, such as response codes, to maps with string keys
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			t[k] = normalize(value)
+		}
+		return t
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, value := range t {
+			m[fmt.Sprint(k)] = normalize(value)
+		}
+		return m
+	case []interface{}:
+		for i := range t {
+			t[i] = normalize(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+func str(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func list(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+func integer(v interface{}) int {
+	switch t := v.(type) {
+	case int:
+		return t
+	case float64:
+		return int(t)
+	}
+	return 0
+}
@@ -13,3 +13,9 @@ type MockEndpoint interface {
 	ValidMethod(string) bool
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
 }
+
+// TokenTypeEndpoint is implemented by endpoints that only accept some token types for a method, e.g. user access tokens.
+// Methods without accepted token types accept both app and user access tokens.
+type TokenTypeEndpoint interface {
+	GetAcceptedTokenTypes(method string) []string
+}
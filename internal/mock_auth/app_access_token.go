@@ -20,6 +20,10 @@ type AppAccessTokenRequestBody struct {
 	ClientSecret string `json:"client_secret"`
 	GrantType    string `json:"grant_type"`
 	Scope        string `json:"scope"`
+	Code         string `json:"code"`
+	RedirectURI  string `json:"redirect_uri"`
+	RefreshToken string `json:"refresh_token"`
+	DeviceCode   string `json:"device_code"`
 }
 
 type AppAccessTokenEndpointResponse struct {
@@ -28,6 +32,7 @@ type AppAccessTokenEndpointResponse struct {
 	ExpiresIn    int      `json:"expires_in"`
 	Scope        []string `json:"scope"`
 	TokenType    string   `json:"token_type"`
+	IDToken      string   `json:"id_token,omitempty"`
 }
 
 func (e AppAccessTokenEndpoint) Path() string { return "/token" }
@@ -40,18 +45,50 @@ func (e AppAccessTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	params, err := parseTokenRequest(r)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, err.Error())
+		return
+	}
+
+	switch params.GrantType {
+	case "client_credentials":
+		clientCredentialsGrant(w, r, params)
+	case "authorization_code":
+		authorizationCodeGrant(w, r, params)
+	case "refresh_token":
+		refreshTokenGrant(w, r, params)
+	case deviceCodeGrantType:
+		deviceCodeGrant(w, r, params)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// parseTokenRequest reads the token request parameters from the query string, with any form body values taking precedence
+func parseTokenRequest(r *http.Request) (AppAccessTokenRequestBody, error) {
 	params := AppAccessTokenRequestBody{
 		ClientID:     r.URL.Query().Get("client_id"),
 		ClientSecret: r.URL.Query().Get("client_secret"),
 		GrantType:    r.URL.Query().Get("grant_type"),
 		Scope:        r.URL.Query().Get("scope"),
+		Code:         r.URL.Query().Get("code"),
+		RedirectURI:  r.URL.Query().Get("redirect_uri"),
+		RefreshToken: r.URL.Query().Get("refresh_token"),
+		DeviceCode:   r.URL.Query().Get("device_code"),
 	}
 
-	if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
-		err := r.ParseForm()
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "application/x-www-form-urlencoded" || strings.HasPrefix(contentType, "multipart/form-data") {
+		var err error
+		if strings.HasPrefix(contentType, "multipart/form-data") {
+			// the CLI's Device Code Flow login sends multipart forms, like production expects
+			err = r.ParseMultipartForm(1 << 20)
+		} else {
+			err = r.ParseForm()
+		}
 		if err != nil {
-			mock_errors.WriteServerError(w, err.Error())
-			return
+			return params, err
 		}
 
 		if r.Form.Get("client_id") != "" {
@@ -66,11 +103,30 @@ func (e AppAccessTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request
 		if r.Form.Get("scope") != "" {
 			params.Scope = r.Form.Get("scope")
 		}
+		if r.Form.Get("scopes") != "" {
+			params.Scope = r.Form.Get("scopes")
+		}
+		if r.Form.Get("code") != "" {
+			params.Code = r.Form.Get("code")
+		}
+		if r.Form.Get("redirect_uri") != "" {
+			params.RedirectURI = r.Form.Get("redirect_uri")
+		}
+		if r.Form.Get("refresh_token") != "" {
+			params.RefreshToken = r.Form.Get("refresh_token")
+		}
+		if r.Form.Get("device_code") != "" {
+			params.DeviceCode = r.Form.Get("device_code")
+		}
 	}
 
+	return params, nil
+}
+
+func clientCredentialsGrant(w http.ResponseWriter, r *http.Request, params AppAccessTokenRequestBody) {
 	scopes := strings.Split(params.Scope, " ")
 
-	if params.ClientID == "" || params.ClientSecret == "" || params.GrantType != "client_credentials" {
+	if params.ClientID == "" || params.ClientSecret == "" {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
@@ -102,7 +158,7 @@ func (e AppAccessTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request
 	a := database.Authorization{
 		ClientID:  ac[0].ID,
 		UserID:    "",
-		ExpiresAt: util.GetTimestamp().Add(24 * time.Hour).Format(time.RFC3339),
+		ExpiresAt: util.GetTimestamp().Add(tokenLifetime(ac[0])).Format(time.RFC3339),
 	}
 
 	auth, err := db.NewQuery(r, 100).CreateAuthorization(a)
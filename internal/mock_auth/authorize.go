@@ -0,0 +1,308 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const authorizationCodeLifetime = 10 * time.Minute
+
+var consentTemplate = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Authorize {{.ClientName}}</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto;">
+<h2>{{.ClientName}} wants to access your account</h2>
+<p>This is the Twitch CLI mock authorization page. Choose the user to log in as.</p>
+{{if .Scopes}}<p>Requested scopes:</p>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<form method="get">
+{{range $k, $v := .Params}}<input type="hidden" name="{{$k}}" value="{{$v}}">
+{{end}}<select name="user_id">
+{{range .Users}}<option value="{{.ID}}">{{.DisplayName}} ({{.ID}})</option>
+{{end}}</select>
+<p><button type="submit" name="decision" value="approve">Authorize</button> <button type="submit" name="decision" value="deny">Cancel</button></p>
+</form>
+</body>
+</html>
+`))
+
+type consentPage struct {
+	ClientName string
+	Scopes     []string
+	Params     map[string]string
+	Users      []database.User
+}
+
+// authorize implements GET /authorize for both the authorization code and implicit grant flows, including OIDC ID tokens.
+// When user_id is not provided, a consent page is shown to choose a user; providing user_id approves the request immediately.
+func authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	clientID := q.Get("client_id")
+	redirectURI := q.Get("redirect_uri")
+	responseType := q.Get("response_type")
+	state := q.Get("state")
+	userID := q.Get("user_id")
+	nonce := q.Get("nonce")
+	claimsRequest := q.Get("claims")
+	scopes := splitScopes(q.Get("scope"))
+
+	if clientID == "" {
+		mock_errors.WriteBadRequest(w, "missing client id")
+		return
+	}
+
+	res, err := db.NewQuery(nil, 10).GetAuthenticationClient(database.AuthenticationClient{ID: clientID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := res.Data.([]database.AuthenticationClient)
+	if len(clients) == 0 {
+		mock_errors.WriteBadRequest(w, "invalid client")
+		return
+	}
+
+	// errors are only returned to the redirect_uri once it is known to be valid
+	redirect, err := url.Parse(redirectURI)
+	if redirectURI == "" || err != nil || redirect.Scheme == "" || redirect.Host == "" {
+		mock_errors.WriteBadRequest(w, "missing or invalid redirect_uri")
+		return
+	}
+
+	// implicit grant responses are returned in the fragment, everything else in the query string
+	useFragment := responseType != "code"
+
+	// the implicit flow can return an access token, an OIDC ID token, or both
+	responseTypes := map[string]bool{}
+	for _, t := range strings.Fields(responseType) {
+		responseTypes[t] = true
+	}
+	validResponseType := responseType == "code" ||
+		(len(responseTypes) == 1 && (responseTypes["token"] || responseTypes["id_token"])) ||
+		(len(responseTypes) == 2 && responseTypes["token"] && responseTypes["id_token"])
+
+	if !validResponseType {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"unsupported_response_type"}, "error_description": {"response_type must be code, token, id_token, or token id_token"}, "state": {state}})
+		return
+	}
+
+	if !areValidScopes(scopes, USER_ACCESS_TOKEN) {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"invalid_scope"}, "error_description": {"Invalid scopes requested"}, "state": {state}})
+		return
+	}
+
+	if responseTypes["id_token"] && !hasScope(scopes, openIDScope) {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"invalid_scope"}, "error_description": {"The openid scope is required to request an id_token"}, "state": {state}})
+		return
+	}
+
+	if _, err := parseClaimsRequest(claimsRequest); err != nil {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"invalid_request"}, "error_description": {"Parameter claims must be valid JSON"}, "state": {state}})
+		return
+	}
+
+	if userID == "" {
+		writeConsentPage(w, r, clients[0], scopes)
+		return
+	}
+
+	if q.Get("decision") == "deny" {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"access_denied"}, "error_description": {"The user denied you access"}, "state": {state}})
+		return
+	}
+
+	res, err = db.NewQuery(nil, 10).GetUsers(database.User{ID: userID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if len(res.Data.([]database.User)) == 0 {
+		redirectWithParams(w, r, redirect, useFragment, url.Values{"error": {"invalid_request"}, "error_description": {"User ID invalid"}, "state": {state}})
+		return
+	}
+
+	if responseType == "code" {
+		code, err := db.NewQuery(nil, 100).CreateAuthorizationCode(database.AuthorizationCode{
+			ClientID:    clientID,
+			UserID:      userID,
+			RedirectURI: redirectURI,
+			Scopes:      strings.Join(scopes, " "),
+			ExpiresAt:   util.GetTimestamp().Add(authorizationCodeLifetime).Format(time.RFC3339),
+			Nonce:       nonce,
+			Claims:      claimsRequest,
+		})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+
+		redirectWithParams(w, r, redirect, false, url.Values{"code": {code.Code}, "scope": {strings.Join(scopes, " ")}, "state": {state}})
+		return
+	}
+
+	params := url.Values{"scope": {strings.Join(scopes, " ")}, "state": {state}}
+
+	if responseTypes["token"] {
+		auth, err := db.NewQuery(nil, 100).CreateAuthorization(database.Authorization{
+			ClientID:  clientID,
+			UserID:    userID,
+			ExpiresAt: util.GetTimestamp().Add(tokenLifetime(clients[0])).Format(time.RFC3339),
+			Scopes:    strings.Join(scopes, " "),
+			Claims:    claimsRequest,
+		})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+
+		// implicit grant tokens can't be refreshed
+		params.Set("access_token", auth.Token)
+		params.Set("token_type", "bearer")
+	}
+
+	if responseTypes["id_token"] {
+		idToken, err := createIDToken(r, UserTokenEndpoint{}.Path(), clientID, userID, scopes, nonce, claimsRequest)
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		params.Set("id_token", idToken)
+	}
+
+	redirectWithParams(w, r, redirect, true, params)
+}
+
+// authorizationCodeGrant exchanges a code from /authorize for a user access token
+func authorizationCodeGrant(w http.ResponseWriter, r *http.Request, params AppAccessTokenRequestBody) {
+	if params.ClientID == "" || params.ClientSecret == "" || params.Code == "" || params.RedirectURI == "" {
+		mock_errors.WriteBadRequest(w, "missing required parameter")
+		return
+	}
+
+	res, err := db.NewQuery(nil, 10).GetAuthenticationClient(database.AuthenticationClient{ID: params.ClientID, Secret: params.ClientSecret})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := res.Data.([]database.AuthenticationClient)
+	if len(clients) == 0 {
+		mock_errors.WriteBadRequest(w, "Client ID/Secret invalid")
+		return
+	}
+
+	code, err := db.NewQuery(nil, 100).RedeemAuthorizationCode(params.Code)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, code.ExpiresAt)
+	if code.Code == "" || code.ClientID != params.ClientID || code.RedirectURI != params.RedirectURI || util.GetTimestamp().After(expiresAt) {
+		mock_errors.WriteBadRequest(w, "Invalid authorization code")
+		return
+	}
+
+	a := database.Authorization{
+		ClientID:  code.ClientID,
+		UserID:    code.UserID,
+		ExpiresAt: util.GetTimestamp().Add(tokenLifetime(clients[0])).Format(time.RFC3339),
+		Scopes:    code.Scopes,
+		Claims:    code.Claims,
+	}
+
+	auth, err := db.NewQuery(nil, 100).CreateAuthorization(a)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	ea, _ := time.Parse(time.RFC3339, a.ExpiresAt)
+	ater := AppAccessTokenEndpointResponse{
+		AccessToken:  auth.Token,
+		RefreshToken: auth.RefreshToken,
+		ExpiresIn:    int(ea.Sub(time.Now().UTC()).Seconds()),
+		Scope:        splitScopes(code.Scopes),
+		TokenType:    "bearer",
+	}
+
+	if hasScope(ater.Scope, openIDScope) {
+		ater.IDToken, err = createIDToken(r, AppAccessTokenEndpoint{}.Path(), code.ClientID, code.UserID, ater.Scope, code.Nonce, code.Claims)
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+	}
+
+	bytes, _ := json.Marshal(ater)
+	w.Write(bytes)
+}
+
+func writeConsentPage(w http.ResponseWriter, r *http.Request, client database.AuthenticationClient, scopes []string) {
+	res, err := db.NewQuery(nil, 100).GetUsers(database.User{})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	params := map[string]string{}
+	for k := range r.URL.Query() {
+		if k != "user_id" && k != "decision" {
+			params[k] = r.URL.Query().Get(k)
+		}
+	}
+
+	name := client.Name
+	if name == "" {
+		name = client.ID
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	consentTemplate.Execute(w, consentPage{
+		ClientName: name,
+		Scopes:     scopes,
+		Params:     params,
+		Users:      res.Data.([]database.User),
+	})
+}
+
+// redirectWithParams redirects to the client's redirect_uri, adding params to either the query string or the fragment
+func redirectWithParams(w http.ResponseWriter, r *http.Request, redirect *url.URL, fragment bool, params url.Values) {
+	u := *redirect
+	if params.Get("state") == "" {
+		params.Del("state")
+	}
+
+	if fragment {
+		u.Fragment = ""
+		http.Redirect(w, r, u.String()+"#"+params.Encode(), http.StatusFound)
+		return
+	}
+
+	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func splitScopes(scope string) []string {
+	scopes := []string{}
+	for _, s := range strings.Split(scope, " ") {
+		if s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
@@ -0,0 +1,183 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// variables rather than constants so tests don't need to wait on the poll interval
+var deviceCodeLifetime = 30 * time.Minute
+var deviceCodePollInterval = 5 * time.Second
+
+type DeviceCodeEndpoint struct{}
+
+type DeviceCodeEndpointResponse struct {
+	DeviceCode      string `json:"device_code"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+}
+
+func (e DeviceCodeEndpoint) Path() string { return "/device" }
+
+func (e DeviceCodeEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	params, err := parseTokenRequest(r)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if params.Scope == "" {
+		params.Scope = r.URL.Query().Get("scopes")
+	}
+
+	if params.ClientID == "" {
+		mock_errors.WriteBadRequest(w, "missing client id")
+		return
+	}
+
+	// Device Code Flow is meant for public clients, so no client secret is required
+	res, err := db.NewQuery(nil, 10).GetAuthenticationClient(database.AuthenticationClient{ID: params.ClientID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if len(res.Data.([]database.AuthenticationClient)) == 0 {
+		mock_errors.WriteBadRequest(w, "invalid client")
+		return
+	}
+
+	scopes := splitScopes(params.Scope)
+	if !areValidScopes(scopes, USER_ACCESS_TOKEN) {
+		mock_errors.WriteBadRequest(w, "Invalid scopes requested")
+		return
+	}
+
+	d, err := db.NewQuery(nil, 100).CreateDeviceCode(database.DeviceCode{
+		ClientID:  params.ClientID,
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: util.GetTimestamp().Add(deviceCodeLifetime).Format(time.RFC3339),
+	})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	bytes, _ := json.Marshal(DeviceCodeEndpointResponse{
+		DeviceCode:      d.DeviceCode,
+		ExpiresIn:       int(deviceCodeLifetime.Seconds()),
+		Interval:        int(deviceCodePollInterval.Seconds()),
+		UserCode:        d.UserCode,
+		VerificationURI: fmt.Sprintf("%v/units/device/approve?user_code=%v", util.RequestBaseURL(r), d.UserCode),
+	})
+	w.Write(bytes)
+}
+
+// deviceCodeGrant is polled by the device until the user code is approved through /units/device/approve
+func deviceCodeGrant(w http.ResponseWriter, r *http.Request, params AppAccessTokenRequestBody) {
+	if params.ClientID == "" || params.DeviceCode == "" {
+		mock_errors.WriteBadRequest(w, "missing required parameter")
+		return
+	}
+
+	res, err := db.NewQuery(nil, 10).GetAuthenticationClient(database.AuthenticationClient{ID: params.ClientID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := res.Data.([]database.AuthenticationClient)
+	if len(clients) == 0 {
+		mock_errors.WriteBadRequest(w, "invalid client")
+		return
+	}
+
+	d, err := db.NewQuery(nil, 100).GetDeviceCode(params.DeviceCode)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if d.DeviceCode == "" || d.ClientID != params.ClientID {
+		mock_errors.WriteBadRequest(w, "invalid device code")
+		return
+	}
+
+	now := util.GetTimestamp()
+	expiresAt, _ := time.Parse(time.RFC3339, d.ExpiresAt)
+	if now.After(expiresAt) {
+		mock_errors.WriteBadRequest(w, "expired_token")
+		return
+	}
+
+	switch d.Status {
+	case database.DeviceCodeStatusDenied:
+		db.NewQuery(nil, 100).DeleteDeviceCode(d.DeviceCode)
+		mock_errors.WriteBadRequest(w, "access_denied")
+		return
+	case database.DeviceCodeStatusPending:
+		lastPolledAt, err := time.Parse(time.RFC3339Nano, d.LastPolledAt)
+		tooSoon := err == nil && now.Sub(lastPolledAt) < deviceCodePollInterval
+
+		d.LastPolledAt = now.Format(time.RFC3339Nano)
+		err = db.NewQuery(nil, 100).UpdateDeviceCode(d)
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+
+		if tooSoon {
+			mock_errors.WriteBadRequest(w, "slow_down")
+		} else {
+			mock_errors.WriteBadRequest(w, "authorization_pending")
+		}
+		return
+	}
+
+	// approved; device codes can only be exchanged once
+	err = db.NewQuery(nil, 100).DeleteDeviceCode(d.DeviceCode)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	a := database.Authorization{
+		ClientID:  d.ClientID,
+		UserID:    d.UserID,
+		ExpiresAt: util.GetTimestamp().Add(tokenLifetime(clients[0])).Format(time.RFC3339),
+		Scopes:    d.Scopes,
+	}
+
+	auth, err := db.NewQuery(nil, 100).CreateAuthorization(a)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	ea, _ := time.Parse(time.RFC3339, a.ExpiresAt)
+	ater := AppAccessTokenEndpointResponse{
+		AccessToken:  auth.Token,
+		RefreshToken: auth.RefreshToken,
+		ExpiresIn:    int(ea.Sub(time.Now().UTC()).Seconds()),
+		Scope:        splitScopes(d.Scopes),
+		TokenType:    "bearer",
+	}
+	bytes, _ := json.Marshal(ater)
+	w.Write(bytes)
+}
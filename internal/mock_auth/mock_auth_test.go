@@ -4,16 +4,25 @@ package mock_auth
 
 import (
 	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_units/device"
 	"github.com/twitchdev/twitch-cli/internal/util"
 	"github.com/twitchdev/twitch-cli/test_setup"
 )
@@ -45,6 +54,11 @@ func TestUserToken(t *testing.T) {
 	a.Nil(err, err)
 	a.Equal(400, resp.StatusCode)
 
+	// malformed body
+	resp, err = http.Post(ts.URL+AppAccessTokenEndpoint{}.Path(), "application/x-www-form-urlencoded", strings.NewReader("client_id=%zz"))
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
 	// valid values
 	q.Set("client_id", ac.ID)
 	q.Set("client_secret", ac.Secret)
@@ -129,6 +143,11 @@ func TestAppAccessToken(t *testing.T) {
 	a.Nil(err, err)
 	a.Equal(400, resp.StatusCode)
 
+	// malformed body
+	resp, err = http.Post(ts.URL+AppAccessTokenEndpoint{}.Path(), "application/x-www-form-urlencoded", strings.NewReader("client_id=%zz"))
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
 	// valid values
 	q.Set("client_id", ac.ID)
 	q.Set("client_secret", ac.Secret)
@@ -172,3 +191,487 @@ func baseMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+func TestAuthorize(t *testing.T) {
+	a = test_setup.SetupTestEnv(t)
+	ts := httptest.NewServer(baseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == (AppAccessTokenEndpoint{}).Path() {
+			AppAccessTokenEndpoint{}.ServeHTTP(w, r)
+			return
+		}
+		UserTokenEndpoint{}.ServeHTTP(w, r)
+	})))
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// invalid client
+	resp, err := client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?client_id=potato&redirect_uri=http://localhost:3000&response_type=code")
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// missing redirect_uri
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?client_id=" + ac.ID + "&response_type=code")
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	q := url.Values{}
+	q.Set("client_id", ac.ID)
+	q.Set("redirect_uri", "http://localhost:3000/callback")
+	q.Set("response_type", "code")
+	q.Set("scope", "user:read:email")
+	q.Set("state", "abc")
+
+	// consent page
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+	a.Contains(resp.Header.Get("Content-Type"), "text/html")
+
+	// denied
+	q.Set("user_id", "1")
+	q.Set("decision", "deny")
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	a.Equal(302, resp.StatusCode)
+	location, _ := url.Parse(resp.Header.Get("Location"))
+	a.Equal("access_denied", location.Query().Get("error"))
+	a.Equal("abc", location.Query().Get("state"))
+
+	// invalid scopes
+	q.Del("decision")
+	q.Set("scope", "potato")
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	a.Equal(302, resp.StatusCode)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+	a.Equal("invalid_scope", location.Query().Get("error"))
+
+	// authorization code flow
+	q.Set("scope", "user:read:email")
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	a.Equal(302, resp.StatusCode)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+	a.Equal("/callback", location.Path)
+	a.Equal("abc", location.Query().Get("state"))
+	code := location.Query().Get("code")
+	a.NotEmpty(code)
+
+	tq := url.Values{}
+	tq.Set("client_id", ac.ID)
+	tq.Set("client_secret", ac.Secret)
+	tq.Set("grant_type", "authorization_code")
+	tq.Set("code", code)
+	tq.Set("redirect_uri", "http://localhost:3000/other")
+	resp, err = http.Post(ts.URL+AppAccessTokenEndpoint{}.Path()+"?"+tq.Encode(), "", nil)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// codes are single use, so request a fresh one after the mismatched redirect_uri
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+	tq.Set("code", location.Query().Get("code"))
+	tq.Set("redirect_uri", "http://localhost:3000/callback")
+	resp, err = http.Post(ts.URL+AppAccessTokenEndpoint{}.Path()+"?"+tq.Encode(), "", nil)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var body AppAccessTokenEndpointResponse
+	a.Nil(json.NewDecoder(resp.Body).Decode(&body))
+	a.NotEmpty(body.AccessToken)
+	a.Equal([]string{"user:read:email"}, body.Scope)
+
+	resp, err = http.Post(ts.URL+AppAccessTokenEndpoint{}.Path()+"?"+tq.Encode(), "", nil)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// implicit flow
+	q.Set("response_type", "token")
+	resp, err = client.Get(ts.URL + UserTokenEndpoint{}.Path() + "?" + q.Encode())
+	a.Nil(err)
+	a.Equal(302, resp.StatusCode)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+	fragment, _ := url.ParseQuery(location.Fragment)
+	a.NotEmpty(fragment.Get("access_token"))
+	a.Equal("bearer", fragment.Get("token_type"))
+	a.Equal("abc", fragment.Get("state"))
+}
+
+func TestRefreshAndRevoke(t *testing.T) {
+	a = test_setup.SetupTestEnv(t)
+	ts := httptest.NewServer(baseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case (AppAccessTokenEndpoint{}).Path():
+			AppAccessTokenEndpoint{}.ServeHTTP(w, r)
+		case (RevokeTokenEndpoint{}).Path():
+			RevokeTokenEndpoint{}.ServeHTTP(w, r)
+		default:
+			UserTokenEndpoint{}.ServeHTTP(w, r)
+		}
+	})))
+
+	q := url.Values{}
+	q.Set("client_id", ac.ID)
+	q.Set("client_secret", ac.Secret)
+	q.Set("grant_type", "user_token")
+	q.Set("user_id", "1")
+	resp, err := http.Post(ts.URL+UserTokenEndpoint{}.Path()+"?"+q.Encode(), "", nil)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var first AppAccessTokenEndpointResponse
+	a.Nil(json.NewDecoder(resp.Body).Decode(&first))
+	a.NotEmpty(first.RefreshToken)
+
+	// refresh tokens are rotated
+	rq := url.Values{}
+	rq.Set("client_id", ac.ID)
+	rq.Set("client_secret", ac.Secret)
+	rq.Set("grant_type", "refresh_token")
+	rq.Set("refresh_token", first.RefreshToken)
+	resp, err = http.PostForm(ts.URL+AppAccessTokenEndpoint{}.Path(), rq)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var second AppAccessTokenEndpointResponse
+	a.Nil(json.NewDecoder(resp.Body).Decode(&second))
+	a.NotEqual(first.AccessToken, second.AccessToken)
+	a.NotEqual(first.RefreshToken, second.RefreshToken)
+
+	resp, err = http.PostForm(ts.URL+AppAccessTokenEndpoint{}.Path(), rq)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// revoking
+	vq := url.Values{}
+	vq.Set("client_id", "potato")
+	vq.Set("token", second.AccessToken)
+	resp, err = http.PostForm(ts.URL+RevokeTokenEndpoint{}.Path(), vq)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	vq.Set("client_id", ac.ID)
+	resp, err = http.PostForm(ts.URL+RevokeTokenEndpoint{}.Path(), vq)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	resp, err = http.PostForm(ts.URL+RevokeTokenEndpoint{}.Path(), vq)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	// the refresh token is revoked along with the access token
+	rq.Set("refresh_token", second.RefreshToken)
+	resp, err = http.PostForm(ts.URL+AppAccessTokenEndpoint{}.Path(), rq)
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+}
+
+func TestDeviceCode(t *testing.T) {
+	a = test_setup.SetupTestEnv(t)
+	ts := httptest.NewServer(baseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case (AppAccessTokenEndpoint{}).Path():
+			AppAccessTokenEndpoint{}.ServeHTTP(w, r)
+		case (device.Endpoint{}).Path():
+			device.Endpoint{}.ServeHTTP(w, r)
+		default:
+			DeviceCodeEndpoint{}.ServeHTTP(w, r)
+		}
+	})))
+
+	start := func() DeviceCodeEndpointResponse {
+		resp, err := http.PostForm(ts.URL+DeviceCodeEndpoint{}.Path(), url.Values{"client_id": {ac.ID}, "scopes": {"user:read:email"}})
+		a.Nil(err)
+		a.Equal(200, resp.StatusCode)
+
+		var body DeviceCodeEndpointResponse
+		a.Nil(json.NewDecoder(resp.Body).Decode(&body))
+		return body
+	}
+	poll := func(deviceCode string) (int, string) {
+		resp, err := http.PostForm(ts.URL+AppAccessTokenEndpoint{}.Path(), url.Values{"client_id": {ac.ID}, "device_code": {deviceCode}, "grant_type": {deviceCodeGrantType}})
+		a.Nil(err)
+
+		var body struct {
+			Message     string `json:"message"`
+			AccessToken string `json:"access_token"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		if resp.StatusCode == 200 {
+			return resp.StatusCode, body.AccessToken
+		}
+		return resp.StatusCode, body.Message
+	}
+	approve := func(q string) int {
+		resp, err := http.Post(ts.URL+(device.Endpoint{}).Path()+"?"+q, "", nil)
+		a.Nil(err)
+		return resp.StatusCode
+	}
+
+	resp, err := http.PostForm(ts.URL+DeviceCodeEndpoint{}.Path(), url.Values{"client_id": {"potato"}})
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+
+	d := start()
+	a.NotEmpty(d.DeviceCode)
+	a.Len(d.UserCode, 8)
+	a.Equal(5, d.Interval)
+	a.Equal(ts.URL+"/units/device/approve?user_code="+d.UserCode, d.VerificationURI)
+
+	status, msg := poll(d.DeviceCode)
+	a.Equal(400, status)
+	a.Equal("authorization_pending", msg)
+	status, msg = poll(d.DeviceCode)
+	a.Equal(400, status)
+	a.Equal("slow_down", msg)
+
+	defaultInterval := deviceCodePollInterval
+	deviceCodePollInterval = 0
+	defer func() { deviceCodePollInterval = defaultInterval }()
+
+	a.Equal(400, approve("user_code="+d.UserCode))
+	a.Equal(404, approve("user_id=1&user_code=AAAAAAAA"))
+	a.Equal(204, approve("user_id=1&user_code="+d.UserCode))
+	a.Equal(400, approve("user_id=1&user_code="+d.UserCode))
+
+	status, token := poll(d.DeviceCode)
+	a.Equal(200, status)
+	a.NotEmpty(token)
+
+	// device codes are single use
+	status, msg = poll(d.DeviceCode)
+	a.Equal(400, status)
+	a.Equal("invalid device code", msg)
+
+	// denied
+	d = start()
+	a.Equal(204, approve("decision=deny&user_code="+d.UserCode))
+	status, msg = poll(d.DeviceCode)
+	a.Equal(400, status)
+	a.Equal("access_denied", msg)
+
+	// expired
+	defaultLifetime := deviceCodeLifetime
+	deviceCodeLifetime = -time.Minute
+	defer func() { deviceCodeLifetime = defaultLifetime }()
+
+	d = start()
+	status, msg = poll(d.DeviceCode)
+	a.Equal(400, status)
+	a.Equal("expired_token", msg)
+}
+
+func TestOIDC(t *testing.T) {
+	a = test_setup.SetupTestEnv(t)
+	ts := httptest.NewServer(baseMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, e := range All() {
+			if r.URL.Path == e.Path() {
+				e.ServeHTTP(w, r)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})))
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// discovery
+	resp, err := http.Get(ts.URL + DiscoveryEndpoint{}.Path())
+	a.Nil(err)
+	var config OpenIDConfiguration
+	a.Nil(json.NewDecoder(resp.Body).Decode(&config))
+	a.Equal(ts.URL, config.Issuer)
+	a.Equal(ts.URL+"/keys", config.JWKSURI)
+
+	// behind a TLS-terminating proxy, the issuer matches the URL clients use
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+DiscoveryEndpoint{}.Path(), nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	var proxied OpenIDConfiguration
+	a.Nil(json.NewDecoder(resp.Body).Decode(&proxied))
+	a.Equal(strings.Replace(ts.URL, "http://", "https://", 1), proxied.Issuer)
+
+	resp, err = http.Get(config.JWKSURI)
+	a.Nil(err)
+	var keys JSONWebKeySet
+	a.Nil(json.NewDecoder(resp.Body).Decode(&keys))
+	a.Len(keys.Keys, 1)
+	n, _ := base64.RawURLEncoding.DecodeString(keys.Keys[0].Modulus)
+	e, _ := base64.RawURLEncoding.DecodeString(keys.Keys[0].Exponent)
+	publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	verify := func(token string) IDTokenClaims {
+		parts := strings.Split(token, ".")
+		a.Len(parts, 3)
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		a.Nil(rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature))
+
+		var claims IDTokenClaims
+		payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		a.Nil(json.Unmarshal(payload, &claims))
+		return claims
+	}
+
+	q := url.Values{}
+	q.Set("client_id", ac.ID)
+	q.Set("redirect_uri", "http://localhost:3000/callback")
+	q.Set("response_type", "id_token")
+	q.Set("scope", "user:read:email")
+	q.Set("user_id", "1")
+	q.Set("nonce", "n-0S6_WzA2Mj")
+	q.Set("claims", `{"id_token":{"email":null,"email_verified":null,"preferred_username":null},"userinfo":{"picture":null}}`)
+
+	// id_token requires the openid scope
+	resp, err = client.Get(config.AuthorizationEndpoint + "?" + q.Encode())
+	a.Nil(err)
+	location, _ := url.Parse(resp.Header.Get("Location"))
+	fragment, _ := url.ParseQuery(location.Fragment)
+	a.Equal("invalid_scope", fragment.Get("error"))
+
+	// implicit flow
+	q.Set("scope", "openid user:read:email")
+	resp, err = client.Get(config.AuthorizationEndpoint + "?" + q.Encode())
+	a.Nil(err)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+	fragment, _ = url.ParseQuery(location.Fragment)
+	a.Empty(fragment.Get("access_token"))
+	claims := verify(fragment.Get("id_token"))
+	a.Equal("1", claims.Subject)
+	a.Equal(ac.ID, claims.Audience)
+	a.Equal(ts.URL, claims.Issuer)
+	a.Equal("n-0S6_WzA2Mj", claims.Nonce)
+	a.NotEmpty(claims.PreferredUsername)
+	a.NotNil(claims.EmailVerified)
+	a.Empty(claims.Picture)
+
+	// authorization code flow
+	q.Set("response_type", "code")
+	q.Set("scope", "openid")
+	resp, err = client.Get(config.AuthorizationEndpoint + "?" + q.Encode())
+	a.Nil(err)
+	location, _ = url.Parse(resp.Header.Get("Location"))
+
+	tq := url.Values{}
+	tq.Set("client_id", ac.ID)
+	tq.Set("client_secret", ac.Secret)
+	tq.Set("grant_type", "authorization_code")
+	tq.Set("code", location.Query().Get("code"))
+	tq.Set("redirect_uri", "http://localhost:3000/callback")
+	resp, err = http.PostForm(config.TokenEndpoint, tq)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var body AppAccessTokenEndpointResponse
+	a.Nil(json.NewDecoder(resp.Body).Decode(&body))
+	claims = verify(body.IDToken)
+	a.Equal("n-0S6_WzA2Mj", claims.Nonce)
+	// email claims need the user:read:email scope
+	a.Nil(claims.EmailVerified)
+
+	// userinfo
+	req, _ = http.NewRequest(http.MethodGet, config.UserInfoEndpoint, nil)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(401, resp.StatusCode)
+
+	req.Header.Set("Authorization", "Bearer "+body.AccessToken)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	var userinfo IDTokenClaims
+	a.Nil(json.NewDecoder(resp.Body).Decode(&userinfo))
+	a.Equal("1", userinfo.Subject)
+	a.NotEmpty(userinfo.Picture)
+	a.Empty(userinfo.PreferredUsername)
+	a.Empty(userinfo.Nonce)
+
+	// requested claims are kept when the token is refreshed
+	rq := url.Values{}
+	rq.Set("client_id", ac.ID)
+	rq.Set("client_secret", ac.Secret)
+	rq.Set("grant_type", "refresh_token")
+	rq.Set("refresh_token", body.RefreshToken)
+	resp, err = http.PostForm(config.TokenEndpoint, rq)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+	a.Nil(json.NewDecoder(resp.Body).Decode(&body))
+
+	req.Header.Set("Authorization", "Bearer "+body.AccessToken)
+	resp, err = http.DefaultClient.Do(req)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+
+	userinfo = IDTokenClaims{}
+	a.Nil(json.NewDecoder(resp.Body).Decode(&userinfo))
+	a.Equal("1", userinfo.Subject)
+	a.NotEmpty(userinfo.Picture)
+}
+
+func TestTokens(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	cliDB, err := database.NewConnection(true)
+	a.Nil(err)
+	defer cliDB.DB.Close()
+
+	client, err := cliDB.NewQuery(nil, 100).InsertOrUpdateAuthenticationClient(database.AuthenticationClient{ID: util.RandomClientID(), Name: "token_client"}, false)
+	a.Nil(err)
+
+	_, _, err = CreateToken(cliDB, CreateTokenParameters{ClientID: client.ID, Scopes: []string{"user:read:email"}})
+	a.NotNil(err)
+	_, _, err = CreateToken(cliDB, CreateTokenParameters{ClientID: client.ID, User: "potato"})
+	a.NotNil(err)
+	_, _, err = CreateToken(cliDB, CreateTokenParameters{ClientID: "potato"})
+	a.NotNil(err)
+
+	appToken, c, err := CreateToken(cliDB, CreateTokenParameters{ClientID: client.ID})
+	a.Nil(err)
+	a.Equal(client.ID, c.ID)
+	a.Empty(appToken.UserID)
+	a.Empty(appToken.RefreshToken)
+
+	userToken, _, err := CreateToken(cliDB, CreateTokenParameters{ClientID: client.ID, User: "1", Scopes: []string{"user:read:email"}, ExpiresIn: time.Hour})
+	a.Nil(err)
+	a.Equal("1", userToken.UserID)
+	a.Equal("user:read:email", userToken.Scopes)
+	a.NotEmpty(userToken.RefreshToken)
+
+	tokens, err := ListTokens(cliDB, "", client.ID)
+	a.Nil(err)
+	a.Len(tokens, 2)
+
+	tokens, err = ListTokens(cliDB, "1", client.ID)
+	a.Nil(err)
+	a.Len(tokens, 1)
+	a.Equal(userToken.Token, tokens[0].Token)
+
+	a.Nil(ExpireToken(cliDB, userToken.Token))
+	auth, err := cliDB.NewQuery(nil, 100).GetAuthorizationByToken(userToken.Token)
+	a.Nil(err)
+	expiresAt, _ := time.Parse(time.RFC3339, auth.ExpiresAt)
+	a.True(util.GetTimestamp().After(expiresAt))
+
+	// expired tokens can still be refreshed
+	auth, err = cliDB.NewQuery(nil, 100).GetAuthorizationByRefreshToken(userToken.RefreshToken)
+	a.Nil(err)
+	a.Equal(userToken.Token, auth.Token)
+
+	a.Nil(RevokeToken(cliDB, userToken.Token))
+	a.NotNil(RevokeToken(cliDB, userToken.Token))
+	a.NotNil(ExpireToken(cliDB, userToken.Token))
+
+	tokens, err = ListTokens(cliDB, "", client.ID)
+	a.Nil(err)
+	a.Len(tokens, 1)
+	a.Equal(appToken.Token, tokens[0].Token)
+}
@@ -0,0 +1,297 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const openIDScope = "openid"
+const idTokenLifetime = time.Hour
+const signingKeyID = "1"
+
+// the signing key is generated once per run, so ID tokens from a previous run of the mock server won't verify against /keys
+var signingKey *rsa.PrivateKey
+var signingKeyOnce sync.Once
+
+// claims that can be requested with the claims parameter, as supported by Twitch
+var supportedClaims = []string{"email", "email_verified", "picture", "preferred_username", "updated_at"}
+
+// ClaimsRequest is the OIDC claims parameter, e.g. {"id_token":{"email":null},"userinfo":{"picture":null}}
+type ClaimsRequest struct {
+	IDToken  map[string]interface{} `json:"id_token"`
+	UserInfo map[string]interface{} `json:"userinfo"`
+}
+
+type IDTokenClaims struct {
+	Audience          string `json:"aud"`
+	Expiration        int64  `json:"exp"`
+	IssuedAt          int64  `json:"iat"`
+	Issuer            string `json:"iss"`
+	Subject           string `json:"sub"`
+	AuthorizedParty   string `json:"azp,omitempty"`
+	Nonce             string `json:"nonce,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+	Picture           string `json:"picture,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+}
+
+type JSONWebKey struct {
+	Algorithm string `json:"alg"`
+	Exponent  string `json:"e"`
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Modulus   string `json:"n"`
+	Use       string `json:"use"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type OpenIDConfiguration struct {
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	ClaimsParameterSupported          bool     `json:"claims_parameter_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	Issuer                            string   `json:"issuer"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+}
+
+type UserInfoEndpoint struct{}
+
+func (e UserInfoEndpoint) Path() string { return "/userinfo" }
+
+func (e UserInfoEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.Header.Get("Authorization")
+	if !strings.HasPrefix(strings.ToLower(token), "bearer ") {
+		mock_errors.WriteUnauthorized(w, "Missing bearer token")
+		return
+	}
+
+	auth, err := db.NewQuery(nil, 100).GetAuthorizationByToken(token[7:])
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	expiresAt, _ := time.Parse(time.RFC3339, auth.ExpiresAt)
+	if auth.ID == 0 || auth.UserID == "" || util.GetTimestamp().After(expiresAt) {
+		mock_errors.WriteUnauthorized(w, "Invalid OAuth token")
+		return
+	}
+
+	scopes := splitScopes(auth.Scopes)
+	if !hasScope(scopes, openIDScope) {
+		mock_errors.WriteUnauthorized(w, "Missing scope: openid")
+		return
+	}
+
+	requested, _ := parseClaimsRequest(auth.Claims)
+	claims, err := newIDTokenClaims(issuer(r, e.Path()), auth.ClientID, auth.UserID, scopes, requested.UserInfo)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	claims.AuthorizedParty = ""
+
+	bytes, _ := json.Marshal(claims)
+	w.Write(bytes)
+}
+
+type KeysEndpoint struct{}
+
+func (e KeysEndpoint) Path() string { return "/keys" }
+
+func (e KeysEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := getSigningKey().PublicKey
+	bytes, _ := json.Marshal(JSONWebKeySet{
+		Keys: []JSONWebKey{
+			{
+				Algorithm: "RS256",
+				Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				KeyID:     signingKeyID,
+				KeyType:   "RSA",
+				Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				Use:       "sig",
+			},
+		},
+	})
+	w.Write(bytes)
+}
+
+type DiscoveryEndpoint struct{}
+
+func (e DiscoveryEndpoint) Path() string { return "/.well-known/openid-configuration" }
+
+func (e DiscoveryEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	iss := issuer(r, e.Path())
+	bytes, _ := json.Marshal(OpenIDConfiguration{
+		AuthorizationEndpoint:             iss + UserTokenEndpoint{}.Path(),
+		ClaimsParameterSupported:          true,
+		ClaimsSupported:                   append([]string{"aud", "exp", "iat", "iss", "sub", "azp", "nonce"}, supportedClaims...),
+		DeviceAuthorizationEndpoint:       iss + DeviceCodeEndpoint{}.Path(),
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		Issuer:                            iss,
+		JWKSURI:                           iss + KeysEndpoint{}.Path(),
+		ResponseTypesSupported:            []string{"id_token", "code", "token", "token id_token"},
+		ScopesSupported:                   []string{openIDScope},
+		SubjectTypesSupported:             []string{"public"},
+		TokenEndpoint:                     iss + AppAccessTokenEndpoint{}.Path(),
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_post"},
+		UserInfoEndpoint:                  iss + UserInfoEndpoint{}.Path(),
+	})
+	w.Write(bytes)
+}
+
+// createIDToken signs an ID token for the user, including any claims requested for the id_token with the claims parameter
+func createIDToken(r *http.Request, path string, clientID string, userID string, scopes []string, nonce string, claimsRequest string) (string, error) {
+	requested, err := parseClaimsRequest(claimsRequest)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := newIDTokenClaims(issuer(r, path), clientID, userID, scopes, requested.IDToken)
+	if err != nil {
+		return "", err
+	}
+	claims.Nonce = nonce
+
+	return signIDToken(claims)
+}
+
+func newIDTokenClaims(iss string, clientID string, userID string, scopes []string, requested map[string]interface{}) (IDTokenClaims, error) {
+	user, err := db.NewQuery(nil, 100).GetUser(database.User{ID: userID})
+	if err != nil {
+		return IDTokenClaims{}, err
+	}
+
+	now := util.GetTimestamp()
+	c := IDTokenClaims{
+		Audience:        clientID,
+		Expiration:      now.Add(idTokenLifetime).Unix(),
+		IssuedAt:        now.Unix(),
+		Issuer:          iss,
+		Subject:         user.ID,
+		AuthorizedParty: clientID,
+	}
+
+	if _, ok := requested["preferred_username"]; ok {
+		c.PreferredUsername = user.DisplayName
+	}
+	if _, ok := requested["picture"]; ok {
+		c.Picture = user.ProfileImageURL
+	}
+	if _, ok := requested["updated_at"]; ok {
+		c.UpdatedAt = user.ModifiedAt
+		if c.UpdatedAt == "" {
+			c.UpdatedAt = user.CreatedAt
+		}
+	}
+	// like production, email claims are only included when the token has the user:read:email scope
+	if hasScope(scopes, "user:read:email") {
+		if _, ok := requested["email"]; ok {
+			c.Email = user.Email
+		}
+		if _, ok := requested["email_verified"]; ok {
+			verified := true
+			c.EmailVerified = &verified
+		}
+	}
+
+	return c, nil
+}
+
+func signIDToken(c IDTokenClaims) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": signingKeyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, getSigningKey(), crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func getSigningKey() *rsa.PrivateKey {
+	signingKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			log.Fatalf("Error generating OIDC signing key: %v", err)
+		}
+		signingKey = key
+	})
+	return signingKey
+}
+
+func parseClaimsRequest(claims string) (ClaimsRequest, error) {
+	var c ClaimsRequest
+	if claims == "" {
+		return c, nil
+	}
+	err := json.Unmarshal([]byte(claims), &c)
+	return c, err
+}
+
+// issuer returns the base URL of the auth namespace, based on the path of the endpoint handling the request
+func issuer(r *http.Request, path string) string {
+	return util.RequestBaseURL(r) + strings.TrimSuffix(r.URL.Path, path)
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// refreshTokenGrant issues a new access and refresh token pair. Refresh tokens are rotated, so the previous pair stops working.
+func refreshTokenGrant(w http.ResponseWriter, r *http.Request, params AppAccessTokenRequestBody) {
+	if params.ClientID == "" || params.ClientSecret == "" || params.RefreshToken == "" {
+		mock_errors.WriteBadRequest(w, "missing required parameter")
+		return
+	}
+
+	res, err := db.NewQuery(nil, 10).GetAuthenticationClient(database.AuthenticationClient{ID: params.ClientID, Secret: params.ClientSecret})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	clients := res.Data.([]database.AuthenticationClient)
+	if len(clients) == 0 {
+		mock_errors.WriteBadRequest(w, "Client ID/Secret invalid")
+		return
+	}
+
+	previous, err := db.NewQuery(nil, 100).GetAuthorizationByRefreshToken(params.RefreshToken)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if previous.ID == 0 || previous.ClientID != params.ClientID {
+		mock_errors.WriteBadRequest(w, "Invalid refresh token")
+		return
+	}
+
+	a := database.Authorization{
+		ClientID:  previous.ClientID,
+		UserID:    previous.UserID,
+		ExpiresAt: util.GetTimestamp().Add(tokenLifetime(clients[0])).Format(time.RFC3339),
+		Scopes:    previous.Scopes,
+		Claims:    previous.Claims,
+	}
+
+	auth, err := db.NewQuery(nil, 100).RotateAuthorization(previous.ID, a)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if auth.Token == "" {
+		// another request rotated the refresh token first
+		mock_errors.WriteBadRequest(w, "Invalid refresh token")
+		return
+	}
+
+	ea, _ := time.Parse(time.RFC3339, a.ExpiresAt)
+	ater := AppAccessTokenEndpointResponse{
+		AccessToken:  auth.Token,
+		RefreshToken: auth.RefreshToken,
+		ExpiresIn:    int(ea.Sub(time.Now().UTC()).Seconds()),
+		Scope:        splitScopes(auth.Scopes),
+		TokenType:    "bearer",
+	}
+	bytes, _ := json.Marshal(ater)
+	w.Write(bytes)
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"log"
+	"net/http"
+	"net/rpc"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	rpc_handler "github.com/twitchdev/twitch-cli/internal/rpc"
+)
+
+type RevokeTokenEndpoint struct{}
+
+func (e RevokeTokenEndpoint) Path() string { return "/revoke" }
+
+func (e RevokeTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	clientID := r.URL.Query().Get("client_id")
+	token := r.URL.Query().Get("token")
+	if r.Header.Get("Content-Type") == "application/x-www-form-urlencoded" {
+		err := r.ParseForm()
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		if r.Form.Get("client_id") != "" {
+			clientID = r.Form.Get("client_id")
+		}
+		if r.Form.Get("token") != "" {
+			token = r.Form.Get("token")
+		}
+	}
+
+	if clientID == "" {
+		mock_errors.WriteBadRequest(w, "missing client id")
+		return
+	}
+
+	auth, err := db.NewQuery(nil, 100).GetAuthorizationByToken(token)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if auth.ID == 0 || auth.ClientID != clientID {
+		mock_errors.WriteBadRequest(w, "Invalid token")
+		return
+	}
+
+	// revoking the access token also invalidates its refresh token, since they share a row
+	err = db.NewQuery(nil, 100).DeleteAuthorization(auth.ID)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	if auth.UserID != "" {
+		notifyAuthorizationRevoke(auth)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// notifyAuthorizationRevoke sends user.authorization.revoke to the mock EventSub WebSocket server, if it's running
+func notifyAuthorizationRevoke(auth database.Authorization) {
+	client, err := rpc.DialHTTP("tcp", ":44747")
+	if err != nil {
+		// the WebSocket server isn't running, so there's nobody to notify
+		return
+	}
+	defer client.Close()
+
+	userLogin := ""
+	user, err := db.NewQuery(nil, 100).GetUser(database.User{ID: auth.UserID})
+	if err == nil {
+		userLogin = user.UserLogin
+	}
+
+	var reply rpc_handler.RPCResponse
+	err = client.Call("RPCHandler.ExecuteGenericRPC", &rpc_handler.RPCArgs{
+		RPCName: "EventSubWebSocketAuthorizationRevoke",
+		Variables: map[string]string{
+			"ClientID":  auth.ClientID,
+			"UserID":    auth.UserID,
+			"UserLogin": userLogin,
+		},
+	}, &reply)
+	if err != nil {
+		log.Printf("Failed to send user.authorization.revoke to the WebSocket server: %v", err)
+	} else if reply.ResponseCode != 0 {
+		log.Printf("WebSocket server failed to process user.authorization.revoke: %v", reply.DetailedInfo)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// CreateTokenParameters describe a token created directly in the database, without going through an OAuth flow
+type CreateTokenParameters struct {
+	ClientID  string // defaults to the first non-extension client
+	User      string // user ID or login; an app access token is created if empty
+	Scopes    []string
+	ExpiresIn time.Duration // defaults to the client's token lifetime
+}
+
+// CreateToken creates an access token for the mock API, returning it along with the client it was issued to
+func CreateToken(cliDB database.CLIDatabase, p CreateTokenParameters) (database.Authorization, database.AuthenticationClient, error) {
+	db = cliDB
+
+	client, err := getTokenClient(p.ClientID)
+	if err != nil {
+		return database.Authorization{}, client, err
+	}
+
+	userID := ""
+	tokenType := APP_ACCES_TOKEN
+	if p.User != "" {
+		user, err := getTokenUser(p.User)
+		if err != nil {
+			return database.Authorization{}, client, err
+		}
+		userID = user.ID
+		tokenType = USER_ACCESS_TOKEN
+	}
+
+	if !areValidScopes(p.Scopes, tokenType) {
+		return database.Authorization{}, client, fmt.Errorf("Invalid scopes requested for %v token: %v", strings.TrimSuffix(tokenType, "_access"), strings.Join(p.Scopes, " "))
+	}
+
+	expiresIn := p.ExpiresIn
+	if expiresIn <= 0 {
+		expiresIn = tokenLifetime(client)
+	}
+
+	auth, err := db.NewQuery(nil, 100).CreateAuthorization(database.Authorization{
+		ClientID:  client.ID,
+		UserID:    userID,
+		ExpiresAt: util.GetTimestamp().Add(expiresIn).Format(time.RFC3339),
+		Scopes:    strings.Join(p.Scopes, " "),
+	})
+	return auth, client, err
+}
+
+// ListTokens returns all tokens, optionally filtered to a user (by ID or login) and client
+func ListTokens(cliDB database.CLIDatabase, user string, clientID string) ([]database.Authorization, error) {
+	db = cliDB
+
+	filter := database.Authorization{ClientID: clientID}
+	if user != "" {
+		u, err := getTokenUser(user)
+		if err != nil {
+			return nil, err
+		}
+		filter.UserID = u.ID
+	}
+
+	return db.NewQuery(nil, 100).GetAuthorizations(filter)
+}
+
+// ExpireToken expires the token immediately, while keeping its refresh token valid so refreshing can be tested
+func ExpireToken(cliDB database.CLIDatabase, token string) error {
+	db = cliDB
+
+	auth, err := getToken(token)
+	if err != nil {
+		return err
+	}
+
+	return db.NewQuery(nil, 100).UpdateAuthorizationExpiration(auth.ID, util.GetTimestamp().Add(-time.Second).Format(time.RFC3339))
+}
+
+// RevokeToken deletes the token and its refresh token, the same as POST /auth/revoke
+func RevokeToken(cliDB database.CLIDatabase, token string) error {
+	db = cliDB
+
+	auth, err := getToken(token)
+	if err != nil {
+		return err
+	}
+
+	err = db.NewQuery(nil, 100).DeleteAuthorization(auth.ID)
+	if err != nil {
+		return err
+	}
+
+	if auth.UserID != "" {
+		notifyAuthorizationRevoke(auth)
+	}
+	return nil
+}
+
+func getToken(token string) (database.Authorization, error) {
+	auth, err := db.NewQuery(nil, 100).GetAuthorizationByToken(token)
+	if err != nil {
+		return auth, err
+	}
+	if auth.ID == 0 {
+		return auth, errors.New("Token not found")
+	}
+	return auth, nil
+}
+
+func getTokenClient(clientID string) (database.AuthenticationClient, error) {
+	res, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: clientID})
+	if err != nil {
+		return database.AuthenticationClient{}, err
+	}
+
+	for _, c := range res.Data.([]database.AuthenticationClient) {
+		if clientID != "" || !c.IsExtension {
+			return c, nil
+		}
+	}
+
+	if clientID != "" {
+		return database.AuthenticationClient{}, fmt.Errorf("Client %v not found", clientID)
+	}
+	return database.AuthenticationClient{}, errors.New("No clients found. Run `twitch mock-api generate` first")
+}
+
+func getTokenUser(user string) (database.User, error) {
+	u, err := db.NewQuery(nil, 100).GetUser(database.User{ID: user})
+	if err != nil {
+		return u, err
+	}
+	if u.ID == "" {
+		u, err = db.NewQuery(nil, 100).GetUser(database.User{UserLogin: strings.ToLower(user)})
+		if err != nil {
+			return u, err
+		}
+	}
+	if u.ID == "" {
+		return u, fmt.Errorf("User %v not found", user)
+	}
+	return u, nil
+}
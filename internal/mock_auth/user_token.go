@@ -18,6 +18,12 @@ type UserTokenEndpoint struct{}
 func (e UserTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	db = r.Context().Value("db").(database.CLIDatabase)
 
+	// GET is the standard OAuth authorize flow; POST is the mock-only shortcut for minting user tokens directly
+	if r.Method == http.MethodGet {
+		authorize(w, r)
+		return
+	}
+
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
@@ -67,7 +73,7 @@ func (e UserTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	a := database.Authorization{
 		ClientID:  ac[0].ID,
 		UserID:    userID,
-		ExpiresAt: util.GetTimestamp().Add(24 * time.Hour).Format(time.RFC3339),
+		ExpiresAt: util.GetTimestamp().Add(tokenLifetime(ac[0])).Format(time.RFC3339),
 		Scopes:    strings.Join(scopes, " "),
 	}
 
@@ -79,7 +85,7 @@ func (e UserTokenEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ea, _ := time.Parse(time.RFC3339, a.ExpiresAt)
 	ater := AppAccessTokenEndpointResponse{
 		AccessToken:  auth.Token,
-		RefreshToken: "",
+		RefreshToken: auth.RefreshToken,
 		ExpiresIn:    int(ea.Sub(time.Now().UTC()).Seconds()),
 		Scope:        scopes,
 		TokenType:    "bearer",
@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_files
+
+import (
+	"encoding/csv"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	AnalyticsExtensions = "extensions"
+	AnalyticsGames      = "games"
+)
+
+var extensionReportColumns = []string{
+	"Date", "Extension Name", "Extension Client ID", "Installs", "Uninstalls", "Activations", "Deactivations",
+	"Unique Active Channels", "Renders", "Unique Renders", "Views", "Unique Viewers", "Unique Interactors", "Clicks",
+}
+
+var gameReportColumns = []string{
+	"Date", "Game Name", "Game ID", "Live Views", "Unique Live Viewers", "Hours Watched", "Unique Broadcasters",
+	"Hours Broadcast", "Clip Views", "Clips Created",
+}
+
+type AnalyticsReportEndpoint struct{}
+
+func (e AnalyticsReportEndpoint) Path() string { return "/analytics/" }
+
+// AnalyticsReportURL builds the link returned by the analytics endpoints for a report covering the provided (inclusive) day range
+func AnalyticsReportURL(r *http.Request, kind string, id string, reportType string, startedAt time.Time, endedAt time.Time) string {
+	q := url.Values{}
+	q.Set("started_at", startedAt.Format(time.RFC3339))
+	q.Set("ended_at", endedAt.Format(time.RFC3339))
+
+	return fmt.Sprintf("%v%v%v%v/%v/%v.csv?%v", util.RequestBaseURL(r), Namespace, AnalyticsReportEndpoint{}.Path(), kind, url.PathEscape(id), reportType, q.Encode())
+}
+
+func (e AnalyticsReportEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db := r.Context().Value("db").(database.CLIDatabase)
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// path is in the format of /mock-files/analytics/<kind>/<id>/<type>.csv
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, Namespace+e.Path()), "/")
+	if len(parts) != 3 || !strings.HasSuffix(parts[2], ".csv") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	kind, id := parts[0], parts[1]
+
+	startedAt, err := time.Parse(time.RFC3339, r.URL.Query().Get("started_at"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	endedAt, err := time.Parse(time.RFC3339, r.URL.Query().Get("ended_at"))
+	if err != nil || endedAt.Before(startedAt) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var name string
+	var columns []string
+	switch kind {
+	case AnalyticsExtensions:
+		res, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: id, IsExtension: true})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		clients := res.Data.([]database.AuthenticationClient)
+		if len(clients) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		name = clients[0].Name
+		columns = extensionReportColumns
+	case AnalyticsGames:
+		res, err := db.NewQuery(nil, 100).GetCategories(database.Category{ID: id})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		categories := res.Data.([]database.Category)
+		if len(categories) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		name = categories[0].Name
+		columns = gameReportColumns
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", fmt.Sprintf("%v_%v_%v", kind, id, parts[2])))
+
+	cw := csv.NewWriter(w)
+	cw.Write(columns)
+
+	// one row per day, newest first, which matches the ordering of the production reports
+	for day := endedAt; !day.Before(startedAt); day = day.AddDate(0, 0, -1) {
+		date := day.Format("2006-01-02")
+		row := []string{date, name, id}
+		for i := 3; i < len(columns); i++ {
+			row = append(row, fmt.Sprint(reportValue(id, date, columns[i])))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+}
+
+// reportValue generates a stable value for a given cell so that re-downloading a report yields the same file
+func reportValue(id string, date string, column string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(id + date + column))
+	return h.Sum32() % 5000
+}
@@ -0,0 +1,22 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_files
+
+import (
+	"net/http"
+)
+
+// Namespace is the top-level path that files generated by the mock server are served from
+const Namespace = "/mock-files"
+
+// FileEndpoint serves generated files (such as analytics reports) that mock endpoints link to
+type FileEndpoint interface {
+	Path() string
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+func All() []FileEndpoint {
+	return []FileEndpoint{
+		AnalyticsReportEndpoint{},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mock_files
+
+import (
+	"context"
+	"encoding/csv"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/util"
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestAnalyticsReport(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	db, err := database.NewConnection(true)
+	a.Nil(err)
+	category := database.Category{ID: util.RandomUserID(), Name: "Report Category", IGDB: "1"}
+	a.Nil(db.NewQuery(nil, 100).InsertCategory(category, false))
+	db.DB.Close()
+
+	ts := httptest.NewServer(baseMiddleware(AnalyticsReportEndpoint{}))
+	defer ts.Close()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 1, 7, 0, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest(http.MethodGet, ts.URL, nil)
+	req.Host = ts.Listener.Addr().String()
+	u := AnalyticsReportURL(req, AnalyticsGames, category.ID, "overview_v2", start, end)
+
+	resp, err := http.Get(u)
+	a.Nil(err)
+	a.Equal(200, resp.StatusCode)
+	a.Equal("text/csv", resp.Header.Get("Content-Type"))
+
+	// behind a TLS-terminating proxy, links use the scheme the client used
+	proxied := httptest.NewRequest(http.MethodGet, ts.URL, nil)
+	proxied.Header.Set("X-Forwarded-Proto", "https")
+	a.True(strings.HasPrefix(AnalyticsReportURL(proxied, AnalyticsGames, category.ID, "overview_v2", start, end), "https://"))
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	a.Nil(err)
+	a.Len(rows, 8)
+	a.Equal(gameReportColumns, rows[0])
+	a.Equal("2023-01-07", rows[1][0])
+	a.Equal("Report Category", rows[1][1])
+
+	// reports are stable between downloads
+	resp, err = http.Get(u)
+	a.Nil(err)
+	again, err := csv.NewReader(resp.Body).ReadAll()
+	a.Nil(err)
+	a.Equal(rows, again)
+
+	resp, err = http.Get(ts.URL + Namespace + "/analytics/games/potato/overview_v2.csv?started_at=2023-01-01T00:00:00Z&ended_at=2023-01-02T00:00:00Z")
+	a.Nil(err)
+	a.Equal(404, resp.StatusCode)
+
+	resp, err = http.Get(ts.URL + Namespace + "/analytics/games/" + category.ID + "/overview_v2.csv")
+	a.Nil(err)
+	a.Equal(400, resp.StatusCode)
+}
+
+func baseMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		db, err := database.NewConnection(true)
+		if err != nil {
+			log.Fatalf("Error connecting to database: %v", err.Error())
+			return
+		}
+		defer db.DB.Close()
+
+		r = r.WithContext(context.WithValue(context.Background(), "db", db))
+		next.ServeHTTP(w, r)
+	})
+}
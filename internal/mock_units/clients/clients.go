@@ -5,8 +5,10 @@ package clients
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
 )
 
 type Endpoint struct{}
@@ -22,6 +24,8 @@ func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case http.MethodGet:
 		getClients(w, r)
 		break
+	case http.MethodPatch:
+		patchClients(w, r)
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
@@ -40,3 +44,36 @@ func getClients(w http.ResponseWriter, r *http.Request) {
 	}
 	w.Write(j)
 }
+
+// patchClients sets how long tokens issued to a client are valid for, in seconds. 0 restores the default.
+func patchClients(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter client_id")
+		return
+	}
+
+	tokenLifetime, err := strconv.Atoi(r.URL.Query().Get("token_lifetime"))
+	if err != nil || tokenLifetime < 0 {
+		mock_errors.WriteBadRequest(w, "token_lifetime must be a number of seconds")
+		return
+	}
+
+	s, err := db.NewQuery(nil, 100).GetAuthenticationClient(database.AuthenticationClient{ID: clientID})
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if len(s.Data.([]database.AuthenticationClient)) == 0 {
+		mock_errors.WriteNotFound(w, "Client not found")
+		return
+	}
+
+	err = db.NewQuery(nil, 100).UpdateAuthenticationClientTokenLifetime(clientID, tokenLifetime)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package device
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/twitchdev/twitch-cli/internal/database"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+// Endpoint approves or denies a Device Code Flow user code as a mock user, standing in for twitch.tv/activate
+type Endpoint struct{}
+
+var db database.CLIDatabase
+
+func (e Endpoint) Path() string { return "/device/approve" }
+
+func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	db = r.Context().Value("db").(database.CLIDatabase)
+
+	switch r.Method {
+	// GET is allowed so the verification_uri returned by /auth/device can be opened in a browser
+	case http.MethodGet, http.MethodPost:
+		approveDevice(w, r)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func approveDevice(w http.ResponseWriter, r *http.Request) {
+	userCode := strings.ToUpper(r.URL.Query().Get("user_code"))
+	userID := r.URL.Query().Get("user_id")
+	deny := r.URL.Query().Get("decision") == "deny"
+
+	if userCode == "" {
+		mock_errors.WriteBadRequest(w, "Missing required parameter user_code")
+		return
+	}
+	if userID == "" && !deny {
+		mock_errors.WriteBadRequest(w, "Missing required parameter user_id")
+		return
+	}
+
+	d, err := db.NewQuery(nil, 100).GetDeviceCodeByUserCode(userCode)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+	if d.DeviceCode == "" {
+		mock_errors.WriteNotFound(w, "No device code found with the provided user_code")
+		return
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, d.ExpiresAt)
+	if util.GetTimestamp().After(expiresAt) {
+		mock_errors.WriteBadRequest(w, "The user_code has expired")
+		return
+	}
+	if d.Status != database.DeviceCodeStatusPending {
+		mock_errors.WriteBadRequest(w, "The user_code has already been used")
+		return
+	}
+
+	if deny {
+		d.Status = database.DeviceCodeStatusDenied
+	} else {
+		u, err := db.NewQuery(nil, 100).GetUsers(database.User{ID: userID})
+		if err != nil {
+			mock_errors.WriteServerError(w, err.Error())
+			return
+		}
+		if len(u.Data.([]database.User)) == 0 {
+			mock_errors.WriteBadRequest(w, "User ID invalid")
+			return
+		}
+
+		d.Status = database.DeviceCodeStatusApproved
+		d.UserID = userID
+	}
+
+	err = db.NewQuery(nil, 100).UpdateDeviceCode(d)
+	if err != nil {
+		mock_errors.WriteServerError(w, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
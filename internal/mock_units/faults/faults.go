@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package faults
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/twitchdev/twitch-cli/internal/mock_api/faults"
+	"github.com/twitchdev/twitch-cli/internal/mock_api/mock_errors"
+	"github.com/twitchdev/twitch-cli/internal/models"
+)
+
+type Endpoint struct{}
+
+func (e Endpoint) Path() string { return "/faults" }
+
+func (e Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	engine, ok := r.Context().Value("faults").(*faults.Engine)
+	if !ok {
+		mock_errors.WriteServerError(w, "Fault injection is not enabled")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		getFaults(w, r, engine)
+	case http.MethodPost:
+		postFaults(w, r, engine)
+	case http.MethodDelete:
+		deleteFaults(w, r, engine)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func getFaults(w http.ResponseWriter, r *http.Request, engine *faults.Engine) {
+	bytes, _ := json.Marshal(models.APIResponse{Data: engine.Rules()})
+	w.Write(bytes)
+}
+
+func postFaults(w http.ResponseWriter, r *http.Request, engine *faults.Engine) {
+	var rule faults.Rule
+	err := json.NewDecoder(r.Body).Decode(&rule)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, "error parsing body")
+		return
+	}
+
+	rule, err = engine.Add(rule)
+	if err != nil {
+		mock_errors.WriteBadRequest(w, err.Error())
+		return
+	}
+
+	bytes, _ := json.Marshal(models.APIResponse{Data: []faults.Rule{rule}})
+	w.Write(bytes)
+}
+
+// deleteFaults removes the rule with the provided id, or all rules when no id is provided
+func deleteFaults(w http.ResponseWriter, r *http.Request, engine *faults.Engine) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		engine.Clear()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if !engine.Remove(id) {
+		mock_errors.WriteNotFound(w, "No fault rule found with the provided id")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
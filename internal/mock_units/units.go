@@ -7,6 +7,8 @@ import (
 
 	"github.com/twitchdev/twitch-cli/internal/mock_units/categories"
 	"github.com/twitchdev/twitch-cli/internal/mock_units/clients"
+	"github.com/twitchdev/twitch-cli/internal/mock_units/device"
+	"github.com/twitchdev/twitch-cli/internal/mock_units/faults"
 	"github.com/twitchdev/twitch-cli/internal/mock_units/streams"
 	"github.com/twitchdev/twitch-cli/internal/mock_units/subscriptions"
 	"github.com/twitchdev/twitch-cli/internal/mock_units/tags"
@@ -31,5 +33,7 @@ func All() []UnitEndpoint {
 		streams.Endpoint{},
 		tags.Endpoint{},
 		subscriptions.Endpoint{},
+		faults.Endpoint{},
+		device.Endpoint{},
 	}
 }
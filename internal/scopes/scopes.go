@@ -0,0 +1,244 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package scopes
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	AppAccessToken  = "app_access"
+	UserAccessToken = "user_access"
+)
+
+// Scope names, for referring to scopes in code without string literals. Each is named by Identifier.
+const (
+	AnalyticsReadExtensions        = "analytics:read:extensions"
+	AnalyticsReadGames             = "analytics:read:games"
+	BitsRead                       = "bits:read"
+	ChannelBot                     = "channel:bot"
+	ChannelEditCommercial          = "channel:edit:commercial"
+	ChannelManageAds               = "channel:manage:ads"
+	ChannelManageBroadcast         = "channel:manage:broadcast"
+	ChannelManageExtensions        = "channel:manage:extensions"
+	ChannelManageGuestStar         = "channel:manage:guest_star"
+	ChannelManageModerators        = "channel:manage:moderators"
+	ChannelManagePolls             = "channel:manage:polls"
+	ChannelManagePredictions       = "channel:manage:predictions"
+	ChannelManageRaids             = "channel:manage:raids"
+	ChannelManageRedemptions       = "channel:manage:redemptions"
+	ChannelManageSchedule          = "channel:manage:schedule"
+	ChannelManageVideos            = "channel:manage:videos"
+	ChannelManageVips              = "channel:manage:vips"
+	ChannelModerate                = "channel:moderate"
+	ChannelReadAds                 = "channel:read:ads"
+	ChannelReadCharity             = "channel:read:charity"
+	ChannelReadEditors             = "channel:read:editors"
+	ChannelReadGoals               = "channel:read:goals"
+	ChannelReadGuestStar           = "channel:read:guest_star"
+	ChannelReadHypeTrain           = "channel:read:hype_train"
+	ChannelReadPolls               = "channel:read:polls"
+	ChannelReadPredictions         = "channel:read:predictions"
+	ChannelReadRedemptions         = "channel:read:redemptions"
+	ChannelReadStreamKey           = "channel:read:stream_key"
+	ChannelReadSubscriptions       = "channel:read:subscriptions"
+	ChannelReadVips                = "channel:read:vips"
+	ChatEdit                       = "chat:edit"
+	ChatRead                       = "chat:read"
+	ClipsEdit                      = "clips:edit"
+	ModerationRead                 = "moderation:read"
+	ModeratorManageAnnouncements   = "moderator:manage:announcements"
+	ModeratorManageAutomod         = "moderator:manage:automod"
+	ModeratorManageAutomodSettings = "moderator:manage:automod_settings"
+	ModeratorManageBannedUsers     = "moderator:manage:banned_users"
+	ModeratorManageBlockedTerms    = "moderator:manage:blocked_terms"
+	ModeratorManageChatMessages    = "moderator:manage:chat_messages"
+	ModeratorManageChatSettings    = "moderator:manage:chat_settings"
+	ModeratorManageGuestStar       = "moderator:manage:guest_star"
+	ModeratorManageShieldMode      = "moderator:manage:shield_mode"
+	ModeratorManageShoutouts       = "moderator:manage:shoutouts"
+	ModeratorManageUnbanRequests   = "moderator:manage:unban_requests"
+	ModeratorManageWarnings        = "moderator:manage:warnings"
+	ModeratorReadAutomodSettings   = "moderator:read:automod_settings"
+	ModeratorReadBannedUsers       = "moderator:read:banned_users"
+	ModeratorReadBlockedTerms      = "moderator:read:blocked_terms"
+	ModeratorReadChatMessages      = "moderator:read:chat_messages"
+	ModeratorReadChatSettings      = "moderator:read:chat_settings"
+	ModeratorReadChatters          = "moderator:read:chatters"
+	ModeratorReadFollowers         = "moderator:read:followers"
+	ModeratorReadGuestStar         = "moderator:read:guest_star"
+	ModeratorReadModerators        = "moderator:read:moderators"
+	ModeratorReadShieldMode        = "moderator:read:shield_mode"
+	ModeratorReadShoutouts         = "moderator:read:shoutouts"
+	ModeratorReadSuspiciousUsers   = "moderator:read:suspicious_users"
+	ModeratorReadUnbanRequests     = "moderator:read:unban_requests"
+	ModeratorReadVips              = "moderator:read:vips"
+	ModeratorReadWarnings          = "moderator:read:warnings"
+	OpenID                         = "openid"
+	UserBot                        = "user:bot"
+	UserEdit                       = "user:edit"
+	UserEditBroadcast              = "user:edit:broadcast"
+	UserEditFollows                = "user:edit:follows"
+	UserManageBlockedUsers         = "user:manage:blocked_users"
+	UserManageChatColor            = "user:manage:chat_color"
+	UserManageWhispers             = "user:manage:whispers"
+	UserReadBlockedUsers           = "user:read:blocked_users"
+	UserReadBroadcast              = "user:read:broadcast"
+	UserReadChat                   = "user:read:chat"
+	UserReadEmail                  = "user:read:email"
+	UserReadEmotes                 = "user:read:emotes"
+	UserReadFollows                = "user:read:follows"
+	UserReadModeratedChannels      = "user:read:moderated_channels"
+	UserReadSubscriptions          = "user:read:subscriptions"
+	UserReadWhispers               = "user:read:whispers"
+	UserWriteChat                  = "user:write:chat"
+	WhispersRead                   = "whispers:read"
+)
+
+// Scope is an OAuth scope, as documented at https://dev.twitch.tv/docs/authentication/scopes
+type Scope struct {
+	Name        string
+	Description string
+	TokenTypes  []string // Token types the scope can be requested for
+	Deprecated  bool
+}
+
+var user = []string{UserAccessToken}
+var appAndUser = []string{AppAccessToken, UserAccessToken}
+
+// registry is the single list of scopes known to the CLI; mock_auth validates requested scopes against it,
+// and mock endpoints build their GetRequiredScopes maps from the constants above.
+var registry = []Scope{
+	{Name: AnalyticsReadExtensions, Description: "View analytics data for the Twitch Extensions owned by the authenticated account.", TokenTypes: appAndUser},
+	{Name: AnalyticsReadGames, Description: "View analytics data for the games owned by the authenticated account.", TokenTypes: appAndUser},
+	{Name: BitsRead, Description: "View Bits information for a channel.", TokenTypes: user},
+	{Name: ChannelBot, Description: "Joins your channel's chatroom as a bot user, and perform chat-related actions as that user.", TokenTypes: user},
+	{Name: ChannelEditCommercial, Description: "Run commercials on a channel.", TokenTypes: user},
+	{Name: ChannelManageAds, Description: "Manage ads schedule on a channel.", TokenTypes: user},
+	{Name: ChannelManageBroadcast, Description: "Manage a channel's broadcast configuration, including updating channel configuration and managing stream markers and stream tags.", TokenTypes: user},
+	{Name: ChannelManageExtensions, Description: "Manage a channel's Extension configuration, including activating Extensions.", TokenTypes: user},
+	{Name: ChannelManageGuestStar, Description: "Manage Guest Star for your channel.", TokenTypes: user},
+	{Name: ChannelManageModerators, Description: "Add or remove the moderator role from users in your channel.", TokenTypes: user},
+	{Name: ChannelManagePolls, Description: "Manage a channel's polls.", TokenTypes: user},
+	{Name: ChannelManagePredictions, Description: "Manage a channel's Channel Points Predictions.", TokenTypes: user},
+	{Name: ChannelManageRaids, Description: "Manage a channel raiding another channel.", TokenTypes: user},
+	{Name: ChannelManageRedemptions, Description: "Manage Channel Points custom rewards and their redemptions on a channel.", TokenTypes: user},
+	{Name: ChannelManageSchedule, Description: "Manage a channel's stream schedule.", TokenTypes: user},
+	{Name: ChannelManageVideos, Description: "Manage a channel's videos, including deleting videos.", TokenTypes: user},
+	{Name: ChannelManageVips, Description: "Add or remove the VIP role from users in your channel.", TokenTypes: user},
+	{Name: ChannelModerate, Description: "Perform moderation actions in a channel.", TokenTypes: user},
+	{Name: ChannelReadAds, Description: "Read the ads schedule and details on your channel.", TokenTypes: user},
+	{Name: ChannelReadCharity, Description: "Read charity campaign details and user donations on your channel.", TokenTypes: user},
+	{Name: ChannelReadEditors, Description: "View a list of users with the editor role for a channel.", TokenTypes: user},
+	{Name: ChannelReadGoals, Description: "View Creator Goals for a channel.", TokenTypes: user},
+	{Name: ChannelReadGuestStar, Description: "Read Guest Star details for your channel.", TokenTypes: user},
+	{Name: ChannelReadHypeTrain, Description: "View Hype Train information for a channel.", TokenTypes: user},
+	{Name: ChannelReadPolls, Description: "View a channel's polls.", TokenTypes: user},
+	{Name: ChannelReadPredictions, Description: "View a channel's Channel Points Predictions.", TokenTypes: user},
+	{Name: ChannelReadRedemptions, Description: "View Channel Points custom rewards and their redemptions on a channel.", TokenTypes: user},
+	{Name: ChannelReadStreamKey, Description: "View an authorized user's stream key.", TokenTypes: user},
+	{Name: ChannelReadSubscriptions, Description: "View a list of all subscribers to a channel and check if a user is subscribed to a channel.", TokenTypes: user},
+	{Name: ChannelReadVips, Description: "Read the list of VIPs in your channel.", TokenTypes: user},
+	{Name: ChatEdit, Description: "Send chat messages to a chatroom using an IRC connection.", TokenTypes: user},
+	{Name: ChatRead, Description: "View chat messages sent in a chatroom using an IRC connection.", TokenTypes: user},
+	{Name: ClipsEdit, Description: "Manage Clips for a channel.", TokenTypes: user},
+	{Name: ModerationRead, Description: "View a channel's moderation data including Moderators, Bans, Timeouts, and Automod settings.", TokenTypes: user},
+	{Name: ModeratorManageAnnouncements, Description: "Send announcements in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorManageAutomod, Description: "Manage messages held for review by AutoMod in channels where you are a moderator.", TokenTypes: user},
+	{Name: ModeratorManageAutomodSettings, Description: "Manage a broadcaster's AutoMod settings.", TokenTypes: user},
+	{Name: ModeratorManageBannedUsers, Description: "Ban and unban users.", TokenTypes: user},
+	{Name: ModeratorManageBlockedTerms, Description: "Manage a broadcaster's list of blocked terms.", TokenTypes: user},
+	{Name: ModeratorManageChatMessages, Description: "Delete chat messages in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorManageChatSettings, Description: "Manage a broadcaster's chat room settings.", TokenTypes: user},
+	{Name: ModeratorManageGuestStar, Description: "Manage Guest Star for channels where you are a Guest Star moderator.", TokenTypes: user},
+	{Name: ModeratorManageShieldMode, Description: "Manage a broadcaster's Shield Mode status.", TokenTypes: user},
+	{Name: ModeratorManageShoutouts, Description: "Manage a broadcaster's shoutouts.", TokenTypes: user},
+	{Name: ModeratorManageUnbanRequests, Description: "Manage a broadcaster's unban requests.", TokenTypes: user},
+	{Name: ModeratorManageWarnings, Description: "Warn users in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadAutomodSettings, Description: "View a broadcaster's AutoMod settings.", TokenTypes: user},
+	{Name: ModeratorReadBannedUsers, Description: "Read the list of bans or unbans in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadBlockedTerms, Description: "View a broadcaster's list of blocked terms.", TokenTypes: user},
+	{Name: ModeratorReadChatMessages, Description: "Read deleted chat messages in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadChatSettings, Description: "View a broadcaster's chat room settings.", TokenTypes: user},
+	{Name: ModeratorReadChatters, Description: "View the chatters in a broadcaster's chat room.", TokenTypes: user},
+	{Name: ModeratorReadFollowers, Description: "Read the followers of a broadcaster.", TokenTypes: user},
+	{Name: ModeratorReadGuestStar, Description: "Read Guest Star details for channels where you are a Guest Star moderator.", TokenTypes: user},
+	{Name: ModeratorReadModerators, Description: "Read the list of moderators in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadShieldMode, Description: "View a broadcaster's Shield Mode status.", TokenTypes: user},
+	{Name: ModeratorReadShoutouts, Description: "View a broadcaster's shoutouts.", TokenTypes: user},
+	{Name: ModeratorReadSuspiciousUsers, Description: "Read chat messages from suspicious users and see users flagged as suspicious in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadUnbanRequests, Description: "View a broadcaster's unban requests.", TokenTypes: user},
+	{Name: ModeratorReadVips, Description: "Read the list of VIPs in channels where you have the moderator role.", TokenTypes: user},
+	{Name: ModeratorReadWarnings, Description: "Read warnings in channels where you have the moderator role.", TokenTypes: user},
+	{Name: OpenID, Description: "Request an OpenID Connect ID token for the user.", TokenTypes: user},
+	{Name: UserBot, Description: "Join a specified chat channel as your user and appear as a bot, and perform chat-related actions as your user.", TokenTypes: user},
+	{Name: UserEdit, Description: "Manage a user object.", TokenTypes: user},
+	{Name: UserEditBroadcast, Description: "View and edit a user's broadcasting configuration, including Extension configurations.", TokenTypes: user},
+	{Name: UserEditFollows, Description: "Manage the channels a user follows. The endpoints that used this scope have been removed.", TokenTypes: user, Deprecated: true},
+	{Name: UserManageBlockedUsers, Description: "Manage the block list of a user.", TokenTypes: user},
+	{Name: UserManageChatColor, Description: "Update the color used for the user's name in chat.", TokenTypes: user},
+	{Name: UserManageWhispers, Description: "Receive whispers sent to your user, and send whispers on your user's behalf.", TokenTypes: user},
+	{Name: UserReadBlockedUsers, Description: "View the block list of a user.", TokenTypes: user},
+	{Name: UserReadBroadcast, Description: "View a user's broadcasting configuration, including Extension configurations.", TokenTypes: user},
+	{Name: UserReadChat, Description: "Receive chatroom messages and informational notifications relating to a channel's chatroom.", TokenTypes: user},
+	{Name: UserReadEmail, Description: "View a user's email address.", TokenTypes: user},
+	{Name: UserReadEmotes, Description: "View emotes available to a user.", TokenTypes: user},
+	{Name: UserReadFollows, Description: "View the list of channels a user follows.", TokenTypes: user},
+	{Name: UserReadModeratedChannels, Description: "Read the list of channels you have moderator privileges in.", TokenTypes: user},
+	{Name: UserReadSubscriptions, Description: "View if an authorized user is subscribed to specific channels.", TokenTypes: user},
+	{Name: UserReadWhispers, Description: "Receive whispers sent to your user.", TokenTypes: user},
+	{Name: UserWriteChat, Description: "Send chat messages to a chatroom.", TokenTypes: user},
+	{Name: WhispersRead, Description: "View your whisper messages.", TokenTypes: user},
+}
+
+var byName = func() map[string]Scope {
+	m := map[string]Scope{}
+	for _, s := range registry {
+		m[s.Name] = s
+	}
+	return m
+}()
+
+// All returns every known scope, sorted by name
+func All() []Scope {
+	all := make([]Scope, len(registry))
+	copy(all, registry)
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+func Get(name string) (Scope, bool) {
+	s, ok := byName[name]
+	return s, ok
+}
+
+// IsValid returns whether the scope exists and can be requested for the token type
+func IsValid(name string, tokenType string) bool {
+	s, ok := byName[name]
+	if !ok {
+		return false
+	}
+	return s.AllowsTokenType(tokenType)
+}
+
+func (s Scope) AllowsTokenType(tokenType string) bool {
+	for _, t := range s.TokenTypes {
+		if t == tokenType {
+			return true
+		}
+	}
+	return false
+}
+
+// Identifier returns the name of the constant in this package for a scope, e.g. ChannelReadVips for channel:read:vips
+func Identifier(name string) string {
+	if name == OpenID {
+		return "OpenID"
+	}
+	var b strings.Builder
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool { return r == ':' || r == '_' }) {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package scopes
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/twitchdev/twitch-cli/test_setup"
+)
+
+func TestRegistry(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	all := All()
+	a.Len(all, len(registry))
+	for i, s := range all {
+		a.NotEmpty(s.Description, s.Name)
+		a.NotEmpty(s.TokenTypes, s.Name)
+		if i > 0 {
+			a.NotEqual(all[i-1].Name, s.Name, "duplicate scope")
+		}
+	}
+
+	s, ok := Get("user:bot")
+	a.True(ok)
+	a.Equal([]string{UserAccessToken}, s.TokenTypes)
+	_, ok = Get("potato")
+	a.False(ok)
+
+	a.True(IsValid("analytics:read:games", AppAccessToken))
+	a.True(IsValid("analytics:read:games", UserAccessToken))
+	a.False(IsValid("user:read:email", AppAccessToken))
+	a.False(IsValid("potato", UserAccessToken))
+}
+
+// every scope has a constant, named by Identifier
+func TestIdentifier(t *testing.T) {
+	a := test_setup.SetupTestEnv(t)
+
+	f, err := parser.ParseFile(token.NewFileSet(), "scopes.go", nil, 0)
+	a.Nil(err)
+	constants := map[string]string{}
+	for _, d := range f.Decls {
+		if g, ok := d.(*ast.GenDecl); ok && g.Tok == token.CONST {
+			for _, spec := range g.Specs {
+				v := spec.(*ast.ValueSpec)
+				value, _ := strconv.Unquote(v.Values[0].(*ast.BasicLit).Value)
+				constants[value] = v.Names[0].Name
+			}
+		}
+	}
+
+	for _, s := range registry {
+		a.Equal(Identifier(s.Name), constants[s.Name], s.Name)
+	}
+	a.Equal("ModeratorManageShieldMode", Identifier("moderator:manage:shield_mode"))
+}
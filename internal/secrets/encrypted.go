@@ -0,0 +1,188 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// PassphraseEnv is the environment variable the encrypted store's passphrase is read from; if it's not set, the passphrase is prompted for
+const PassphraseEnv = "TWITCH_SECRETS_PASSPHRASE"
+
+const encryptedFileVersion = 1
+
+// pbkdf2Iterations is the PBKDF2-HMAC-SHA256 work factor for new files; files record the count they were written with
+const pbkdf2Iterations = 600000
+const saltLength = 16
+const keyLength = 32
+
+// the passphrase is only prompted for once per run
+var promptedPassphrase string
+
+// EncryptedFileStore keeps secrets in a file encrypted with AES-256-GCM, using a key derived from a passphrase
+type EncryptedFileStore struct {
+	path       string
+	passphrase string
+}
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// NewEncryptedFileStore returns a store for the file at path. If passphrase is empty, it's read from PassphraseEnv or prompted for when needed.
+func NewEncryptedFileStore(path string, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{path: path, passphrase: passphrase}
+}
+
+func (s *EncryptedFileStore) Name() string { return StoreEncrypted }
+
+func (s *EncryptedFileStore) Path() string { return s.path }
+
+func (s *EncryptedFileStore) Load() (map[string]string, error) {
+	values := map[string]string{}
+
+	content, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var f encryptedFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("Error reading secrets from %v: %v", s.path, err)
+	}
+	if f.Version != encryptedFileVersion {
+		return nil, fmt.Errorf("Unsupported secrets file version %v in %v", f.Version, s.path)
+	}
+
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, f.Nonce, f.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decrypt %v; check the passphrase", s.path)
+	}
+
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (s *EncryptedFileStore) Save(values map[string]string) error {
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	// a new salt and nonce are used for every write
+	f := encryptedFile{
+		Version:    encryptedFileVersion,
+		Iterations: pbkdf2Iterations,
+		Salt:       make([]byte, saltLength),
+	}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	f.Data = gcm.Seal(nil, f.Nonce, plaintext, nil)
+
+	content, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, content, 0600)
+}
+
+// Remove deletes the secrets file
+func (s *EncryptedFileStore) Remove() error {
+	err := os.Remove(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *EncryptedFileStore) getPassphrase() (string, error) {
+	if s.passphrase != "" {
+		return s.passphrase, nil
+	}
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		s.passphrase = p
+		return p, nil
+	}
+	if promptedPassphrase != "" {
+		s.passphrase = promptedPassphrase
+		return promptedPassphrase, nil
+	}
+
+	// only prompt when someone can answer
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return "", fmt.Errorf("A passphrase is required for the encrypted secret store. Set the %v environment variable", PassphraseEnv)
+	}
+
+	prompt := promptui.Prompt{
+		Label: "Secret store passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if s == "" {
+				return errors.New("Passphrase can't be empty")
+			}
+			return nil
+		},
+	}
+	p, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+
+	promptedPassphrase = p
+	s.passphrase = p
+	return p, nil
+}
+
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, errors.New("Invalid iteration count in secrets file")
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, keyLength, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
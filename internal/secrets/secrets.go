@@ -0,0 +1,182 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package secrets
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/twitchdev/twitch-cli/internal/util"
+)
+
+const (
+	StorePlain     = "plain"
+	StoreEncrypted = "encrypted"
+)
+
+// StoreConfigKey selects the secret store in the CLI config; it can also be set with the TWITCH_SECRETSTORE environment variable
+const StoreConfigKey = "secretStore"
+
+// Keys are the config values kept in the secret store rather than the config file
+var Keys = []string{"clientSecret", "accessToken", "refreshToken"}
+
+// Store holds the values of Keys for the active profile
+type Store interface {
+	Load() (map[string]string, error)
+	Save(values map[string]string) error
+	Name() string
+}
+
+// PlainStore keeps secrets in the config file, as the CLI always has
+type PlainStore struct{}
+
+func (s PlainStore) Name() string { return StorePlain }
+
+func (s PlainStore) Load() (map[string]string, error) {
+	values := map[string]string{}
+	for _, k := range Keys {
+		if v := viper.GetString(k); v != "" {
+			values[k] = v
+		}
+	}
+	return values, nil
+}
+
+func (s PlainStore) Save(values map[string]string) error {
+	for k, v := range values {
+		viper.Set(k, v)
+	}
+	return nil
+}
+
+// GetStore returns the store selected in the config; the plain store is used if none is selected
+func GetStore() (Store, error) {
+	switch name := viper.GetString(StoreConfigKey); name {
+	case "", StorePlain:
+		return PlainStore{}, nil
+	case StoreEncrypted:
+		path, err := getConfigPath()
+		if err != nil {
+			return nil, err
+		}
+		return NewEncryptedFileStore(strings.TrimSuffix(path, filepath.Ext(path))+".secrets", ""), nil
+	default:
+		return nil, fmt.Errorf("Invalid secret store %v. Valid values are: %v, %v", name, StorePlain, StoreEncrypted)
+	}
+}
+
+// LoadIntoConfig reads secrets from the store into the config, so they can be read with viper like any other value
+func LoadIntoConfig() error {
+	store, err := GetStore()
+	if err != nil {
+		return err
+	}
+	if _, ok := store.(PlainStore); ok {
+		return nil
+	}
+
+	values, err := store.Load()
+	if err != nil {
+		return err
+	}
+	for k, v := range values {
+		viper.Set(k, v)
+	}
+	return nil
+}
+
+// WriteConfig writes the config for the active profile, moving secrets into the secret store unless it's the plain store
+func WriteConfig() error {
+	path, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+
+	store, err := GetStore()
+	if err != nil {
+		return err
+	}
+	if _, ok := store.(PlainStore); ok {
+		return viper.WriteConfigAs(path)
+	}
+
+	settings := viper.AllSettings()
+	values := map[string]string{}
+	for _, k := range Keys {
+		lower := strings.ToLower(k)
+		if v, ok := settings[lower]; ok {
+			if s := fmt.Sprint(v); s != "" {
+				values[k] = s
+			}
+			delete(settings, lower)
+		}
+	}
+
+	// secrets that weren't loaded into the config are kept as they are
+	if len(values) > 0 {
+		existing, err := store.Load()
+		if err != nil {
+			return err
+		}
+		for k, v := range values {
+			existing[k] = v
+		}
+		if err := store.Save(existing); err != nil {
+			return err
+		}
+	}
+
+	config := viper.New()
+	for k, v := range settings {
+		config.Set(k, v)
+	}
+	config.SetConfigType("env")
+	return config.WriteConfigAs(path)
+}
+
+// Migrate moves secrets for the active profile into the named store, returning the keys that were moved
+func Migrate(to string) ([]string, error) {
+	if err := LoadIntoConfig(); err != nil {
+		return nil, err
+	}
+
+	moved := []string{}
+	for _, k := range Keys {
+		if viper.GetString(k) != "" {
+			moved = append(moved, k)
+		}
+	}
+
+	from, err := GetStore()
+	if err != nil {
+		return nil, err
+	}
+
+	viper.Set(StoreConfigKey, to)
+	if _, err := GetStore(); err != nil {
+		viper.Set(StoreConfigKey, from.Name())
+		return nil, err
+	}
+	if err := WriteConfig(); err != nil {
+		return nil, err
+	}
+
+	// the encrypted file is no longer needed once its secrets are back in the config
+	if e, ok := from.(*EncryptedFileStore); ok && to == StorePlain {
+		if err := e.Remove(); err != nil {
+			return moved, err
+		}
+	}
+
+	return moved, nil
+}
+
+// getConfigPath returns the config file in use, which is only different from the active profile's when --config is set
+func getConfigPath() (string, error) {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path, nil
+	}
+	return util.GetConfigPath()
+}
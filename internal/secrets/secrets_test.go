@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedFileStore(t *testing.T) {
+	a := assert.New(t)
+	path := filepath.Join(t.TempDir(), ".twitch-cli.secrets")
+
+	store := NewEncryptedFileStore(path, "hunter2")
+	values, err := store.Load()
+	a.Nil(err)
+	a.Empty(values)
+
+	err = store.Save(map[string]string{"clientSecret": "secret", "accessToken": "token"})
+	a.Nil(err)
+
+	content, err := os.ReadFile(path)
+	a.Nil(err)
+	a.NotContains(string(content), "secret")
+	a.NotContains(string(content), "token")
+
+	values, err = NewEncryptedFileStore(path, "hunter2").Load()
+	a.Nil(err)
+	a.Equal("secret", values["clientSecret"])
+	a.Equal("token", values["accessToken"])
+
+	_, err = NewEncryptedFileStore(path, "hunter3").Load()
+	a.NotNil(err)
+
+	a.Nil(store.Remove())
+	_, err = os.Stat(path)
+	a.True(os.IsNotExist(err))
+}
+
+func TestMigrate(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv(PassphraseEnv, "hunter2")
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".twitch-cli.env")
+	secretsPath := filepath.Join(dir, ".twitch-cli.secrets")
+	a.Nil(os.WriteFile(configPath, []byte("CLIENTID=client\nCLIENTSECRET=secret\nACCESSTOKEN=token\n"), 0600))
+
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType("env")
+	a.Nil(viper.ReadInConfig())
+
+	_, err := Migrate("potato")
+	a.NotNil(err)
+
+	moved, err := Migrate(StoreEncrypted)
+	a.Nil(err)
+	a.Equal([]string{"clientSecret", "accessToken"}, moved)
+
+	config, err := os.ReadFile(configPath)
+	a.Nil(err)
+	a.Contains(string(config), "CLIENTID=client")
+	a.Contains(string(config), "SECRETSTORE=encrypted")
+	a.NotContains(string(config), "CLIENTSECRET")
+	a.NotContains(string(config), "ACCESSTOKEN")
+
+	// values written later go to the secret store and keep the ones already there
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType("env")
+	a.Nil(viper.ReadInConfig())
+	viper.Set("refreshToken", "refresh")
+	a.Nil(WriteConfig())
+
+	values, err := NewEncryptedFileStore(secretsPath, "").Load()
+	a.Nil(err)
+	a.Equal(map[string]string{"clientSecret": "secret", "accessToken": "token", "refreshToken": "refresh"}, values)
+
+	viper.Reset()
+	viper.SetConfigFile(configPath)
+	viper.SetConfigType("env")
+	a.Nil(viper.ReadInConfig())
+	a.Nil(LoadIntoConfig())
+	a.Equal("secret", viper.GetString("clientSecret"))
+
+	moved, err = Migrate(StorePlain)
+	a.Nil(err)
+	a.Len(moved, 3)
+
+	config, err = os.ReadFile(configPath)
+	a.Nil(err)
+	a.Contains(string(config), "CLIENTSECRET=secret")
+	a.Contains(string(config), "REFRESHTOKEN=refresh")
+	_, err = os.Stat(secretsPath)
+	a.True(os.IsNotExist(err))
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 )
 
 var legacySubFolder = ".twitch-cli"
@@ -49,19 +52,76 @@ func GetApplicationDir() (string, error) {
 	return path, nil
 }
 
-// GetConfigPath returns a string representation of the configuration's path
+// DefaultProfile is the profile stored in the main configuration file
+const DefaultProfile = "default"
+
+var activeProfile = DefaultProfile
+var validProfileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SetProfile changes the profile used by GetConfigPath
+func SetProfile(profile string) error {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	if !validProfileName.MatchString(profile) {
+		return fmt.Errorf("Invalid profile name %v; profile names may only contain letters, numbers, - and _", profile)
+	}
+	activeProfile = profile
+	return nil
+}
+
+// GetProfile returns the name of the profile in use
+func GetProfile() string {
+	return activeProfile
+}
+
+// GetConfigPath returns a string representation of the configuration's path for the active profile
 func GetConfigPath() (string, error) {
+	return GetProfileConfigPath(activeProfile)
+}
+
+// GetProfileConfigPath returns the configuration's path for a profile; profiles other than the default are stored in .twitch-cli.<profile>.env
+func GetProfileConfigPath(profile string) (string, error) {
 	home, err := GetApplicationDir()
 	if err != nil {
 		return "", err
 	}
 
-	configPath := filepath.Join(home, ".twitch-cli.env")
+	name := ".twitch-cli"
 
 	// purely for testing purposes- this allows us to run tests without overwriting the user's config
 	if os.Getenv("GOLANG_TESTING") == "true" {
-		configPath = filepath.Join(home, ".twitch-cli-test.env")
+		name = ".twitch-cli-test"
+	}
+
+	if profile != "" && profile != DefaultProfile {
+		name += "." + profile
+	}
+
+	return filepath.Join(home, name+".env"), nil
+}
+
+// GetProfiles returns the names of all profiles with a configuration file, along with the default profile
+func GetProfiles() ([]string, error) {
+	defaultPath, err := GetProfileConfigPath(DefaultProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(defaultPath, ".env") + "."
+	matches, err := filepath.Glob(prefix + "*.env")
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := []string{DefaultProfile}
+	for _, m := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ".env")
+		if validProfileName.MatchString(name) && name != DefaultProfile {
+			profiles = append(profiles, name)
+		}
 	}
+	sort.Strings(profiles[1:])
 
-	return configPath, nil
+	return profiles, nil
 }
@@ -3,6 +3,7 @@
 package util
 
 import (
+	"os"
 	"strings"
 	"testing"
 
@@ -24,3 +25,33 @@ func TestGetConfigPath(t *testing.T) {
 	a.Nil(err, "GetConfigPath() failed with error  %v", err)
 	a.Equal(true, strings.HasSuffix(config, ".twitch-cli.env"), "GetConfigPath() expected to end with %v, got %v", ".twitch-cli", config)
 }
+
+func TestProfiles(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("GOLANG_TESTING", "true")
+	defer SetProfile(DefaultProfile)
+
+	a.Equal(DefaultProfile, GetProfile())
+	a.NotNil(SetProfile("../potato"))
+	a.Equal(DefaultProfile, GetProfile())
+
+	a.Nil(SetProfile("mock"))
+	a.Equal("mock", GetProfile())
+
+	config, err := GetConfigPath()
+	a.Nil(err)
+	a.True(strings.HasSuffix(config, ".twitch-cli-test.mock.env"), "expected profile config path, got %v", config)
+
+	a.Nil(os.WriteFile(config, []byte{}, 0600))
+	defer os.Remove(config)
+
+	profiles, err := GetProfiles()
+	a.Nil(err)
+	a.Equal(DefaultProfile, profiles[0])
+	a.Contains(profiles, "mock")
+
+	a.Nil(SetProfile(""))
+	config, err = GetConfigPath()
+	a.Nil(err)
+	a.True(strings.HasSuffix(config, ".twitch-cli-test.env"), "expected default config path, got %v", config)
+}
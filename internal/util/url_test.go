@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestBaseURL(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("http://localhost:8080", RequestBaseURL(httptest.NewRequest(http.MethodGet, "http://localhost:8080/auth/device", nil)))
+	a.Equal("https://localhost:8080", RequestBaseURL(httptest.NewRequest(http.MethodGet, "https://localhost:8080/auth/device", nil)))
+
+	r := httptest.NewRequest(http.MethodGet, "http://mock.example.com/auth/device", nil)
+	r.Header.Set("X-Forwarded-Proto", "https, http")
+	a.Equal("https://mock.example.com", RequestBaseURL(r))
+
+	r.Header.Set("X-Forwarded-Proto", "gopher")
+	a.Equal("http://mock.example.com", RequestBaseURL(r))
+}
@@ -83,7 +83,14 @@ func CheckForUpdatesAndPrintNotice() {
 		if err != nil {
 			return
 		}
-		_ = viper.WriteConfigAs(configPath)
+
+		// only update this key on disk, so values loaded from elsewhere (e.g. the secret store) aren't written to the config
+		config := viper.New()
+		config.SetConfigFile(configPath)
+		config.SetConfigType("env")
+		_ = config.ReadInConfig()
+		config.Set("last_update_check", viper.GetString("last_update_check"))
+		_ = config.WriteConfigAs(configPath)
 	}
 }
 